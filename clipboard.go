@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/atotto/clipboard"
+	"github.com/aymanbagabas/go-osc52/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// genericAbbreviations name North American zones without their DST state,
+// as meeting announcements usually do: "3:00 PM ET"
+var genericAbbreviations = map[string]string{
+	"EST": "ET", "EDT": "ET",
+	"CST": "CT", "CDT": "CT",
+	"MST": "MT", "MDT": "MT",
+	"PST": "PT", "PDT": "PT",
+	"AKST": "AKT", "AKDT": "AKT",
+}
+
+// announceQuery renders a conversion as one line to paste into a
+// message: "3:00 PM ET / 9:00 PM CET / 5:00 AM JST (+1)". It returns an
+// empty string for queries that have no such form, such as durations or
+// whole days.
+func (m model) announceQuery(query conversionQuery) string {
+	targets := query.Targets
+	if query.AllTargets {
+		targets = m.clockTargets()
+	}
+
+	resolved, err := resolveTimeExpr(query.TimeExpr, query.SourceLoc, query.Offsets, time.Now(), m.parseOpts)
+	if err != nil || (resolved.Parsed.AllDay() && len(query.Offsets) == 0) {
+		return ""
+	}
+
+	source := resolved.Time
+	parts := []string{announcementTime(source, source, m.format)}
+	for _, t := range targets {
+		parts = append(parts, announcementTime(source.In(t.Loc), source, m.format))
+	}
+
+	announcement := strings.Join(parts, " / ")
+	if resolved.Parsed.Approximate {
+		announcement = "~" + announcement
+	}
+	return announcement
+}
+
+// announcementTime renders t with its zone abbreviation, marking a date that
+// differs from the source date
+func announcementTime(t, source time.Time, f timeFormatter) string {
+	text := f.Time(t) + " " + announcementZone(t)
+	if days := calendarDays(source, t); days != 0 {
+		text += fmt.Sprintf(" (%s)", formatDayDelta(days))
+	}
+	return text
+}
+
+// announcementZone names t's zone for an announcement: "ET", "CET", or
+// "UTC+04" for zones without an abbreviation
+func announcementZone(t time.Time) string {
+	abbreviation := zoneAbbreviation(t)
+	if generic, ok := genericAbbreviations[abbreviation]; ok {
+		return generic
+	} else if abbreviation == "" {
+		// Zones without an abbreviation report their offset: "+04"
+		return "UTC" + t.Format("MST")
+	}
+	return abbreviation
+}
+
+// copyToClipboard copies text to the system clipboard and describes how it
+// was copied. Over SSH, or when no clipboard tool is available, it asks the
+// terminal to copy the text with an OSC 52 escape sequence instead.
+func copyToClipboard(text string) (string, error) {
+	text = strings.TrimSpace(ansi.Strip(text))
+	if text == "" {
+		return "", fmt.Errorf("Nothing to copy")
+	}
+
+	remote := os.Getenv("SSH_TTY") != "" || os.Getenv("SSH_CONNECTION") != ""
+	if !remote && !clipboard.Unsupported {
+		if err := clipboard.WriteAll(text); err == nil {
+			return "Copied to clipboard", nil
+		}
+	}
+
+	seq := osc52.New(text)
+	switch {
+	case os.Getenv("TMUX") != "":
+		seq = seq.Tmux()
+	case os.Getenv("STY") != "":
+		seq = seq.Screen()
+	}
+	if _, err := seq.WriteTo(os.Stderr); err != nil {
+		return "", err
+	}
+	return "Copied to clipboard via the terminal (OSC 52)", nil
+}
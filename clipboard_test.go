@@ -0,0 +1,43 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAnnouncementTime(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("tzdata not available: %v", err)
+	}
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	dubai, _ := time.LoadLocation("Asia/Dubai")
+	honolulu, _ := time.LoadLocation("Pacific/Honolulu")
+
+	winter := time.Date(2026, 1, 19, 15, 0, 0, 0, newYork)
+	summer := time.Date(2026, 7, 20, 9, 0, 0, 0, newYork)
+
+	tests := []struct {
+		name   string
+		t      time.Time
+		source time.Time
+		want   string
+	}{
+		{"source in winter", winter, winter, "3:00 PM ET"},
+		{"source in summer", summer, summer, "9:00 AM ET"},
+		{"same day", winter.In(berlin), winter, "9:00 PM CET"},
+		{"summer time", summer.In(berlin), summer, "3:00 PM CEST"},
+		{"next day", winter.In(tokyo), winter, "5:00 AM JST (+1)"},
+		{"numeric abbreviation", winter.In(dubai), winter, "12:00 AM UTC+04 (+1)"},
+		{"previous day", winter.In(tokyo).In(honolulu), winter.In(tokyo), "10:00 AM HST (-1)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := announcementTime(tt.t, tt.source, defaultFormatter); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
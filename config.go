@@ -2,6 +2,7 @@ package main
 
 import (
 	"aeon/timezones"
+	"bytes"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -12,6 +13,31 @@ import (
 
 type Config struct {
 	Zones []ConfigZone `yaml:"zones"`
+	// Rollover resolves bare times that have already passed today ("3pm" at
+	// 6pm) to their next occurrence instead of earlier today
+	Rollover bool `yaml:"rollover,omitempty"`
+	// DSTNoticeDays is how many days before or after a DST change a
+	// converted time gets a note about it. Zero means the default of 7;
+	// a negative value turns the notes off.
+	DSTNoticeDays int `yaml:"dst_notice_days,omitempty"`
+	// Format controls how dates and times are rendered
+	Format FormatConfig `yaml:"format,omitempty"`
+	// WorkingHours sets the hours the meeting finder looks for, by zone
+	// and by person
+	WorkingHours WorkingHoursConfig `yaml:"working_hours,omitempty"`
+	// Meeting sets how meeting slots are ranked
+	Meeting MeetingConfig `yaml:"meeting,omitempty"`
+}
+
+// defaultDSTNoticeDays is used when the config doesn't set dst_notice_days
+const defaultDSTNoticeDays = 7
+
+// dstNoticeDays returns the configured DST notice window in days
+func (c Config) dstNoticeDays() int {
+	if c.DSTNoticeDays == 0 {
+		return defaultDSTNoticeDays
+	}
+	return c.DSTNoticeDays
 }
 
 type ConfigZone struct {
@@ -27,23 +53,37 @@ func getConfigPath() string {
 	return filepath.Join(home, ".aeon.yaml")
 }
 
-func loadZonesFromConfig() []Zone {
+// loadConfig reads the config file. A missing file is an empty config;
+// one that can't be read or parsed is an error. Invalid sleep hours are
+// reported with the rest of the config, which uses the default ones.
+func loadConfig() (Config, error) {
 	configPath := getConfigPath()
 	if configPath == "" {
-		return nil
+		return Config{}, nil
 	}
 
 	data, err := os.ReadFile(configPath)
+	if os.IsNotExist(err) {
+		return Config{}, nil
+	}
 	if err != nil {
-		// Config doesn't exist or can't be read - return empty
-		return nil
+		return Config{}, fmt.Errorf("Could not read %s: %v", configPath, err)
 	}
 
 	var config Config
 	if err := yaml.Unmarshal(data, &config); err != nil {
-		return nil
+		return Config{}, fmt.Errorf("Could not parse %s: %v", configPath, err)
 	}
+	if config.Meeting.SleepHours != "" {
+		if _, err := parseWorkingHours(config.Meeting.SleepHours); err != nil {
+			return config, fmt.Errorf("Invalid sleep_hours in %s: %v", configPath, err)
+		}
+	}
+
+	return config, nil
+}
 
+func loadZonesFromConfig(config Config) []Zone {
 	zones := make([]Zone, 0, len(config.Zones))
 	for _, cz := range config.Zones {
 		var loc *time.Location
@@ -72,10 +112,13 @@ func loadZonesFromConfig() []Zone {
 	return zones
 }
 
+// saveZonesToConfig writes the zones to the config file. Only the zones
+// entry is replaced, so other settings and comments are kept. A file that
+// doesn't parse is left alone.
 func saveZonesToConfig(zones []Zone) error {
 	configPath := getConfigPath()
 	if configPath == "" {
-		return fmt.Errorf("could not determine config path")
+		return fmt.Errorf("Could not determine config path")
 	}
 
 	configZones := make([]ConfigZone, len(zones))
@@ -86,14 +129,51 @@ func saveZonesToConfig(zones []Zone) error {
 		}
 	}
 
-	config := Config{
-		Zones: configZones,
+	var doc yaml.Node
+	data, err := os.ReadFile(configPath)
+	switch {
+	case os.IsNotExist(err):
+	case err != nil:
+		return fmt.Errorf("Zones not saved, could not read %s: %v", configPath, err)
+	default:
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return fmt.Errorf("Zones not saved, could not parse %s: %v", configPath, err)
+		}
+	}
+
+	// An empty file has no document yet
+	if doc.Kind == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return fmt.Errorf("Zones not saved, %s is not a mapping of settings", configPath)
 	}
 
-	data, err := yaml.Marshal(&config)
-	if err != nil {
+	var value yaml.Node
+	if err := value.Encode(configZones); err != nil {
 		return err
 	}
+	replaced := false
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == "zones" {
+			root.Content[i+1] = &value
+			replaced = true
+		}
+	}
+	if !replaced {
+		key := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "zones"}
+		root.Content = append([]*yaml.Node{key, &value}, root.Content...)
+	}
 
-	return os.WriteFile(configPath, data, 0644)
+	var out bytes.Buffer
+	encoder := yaml.NewEncoder(&out)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return err
+	}
+	if err := encoder.Close(); err != nil {
+		return err
+	}
+	return os.WriteFile(configPath, out.Bytes(), 0644)
 }
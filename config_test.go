@@ -0,0 +1,121 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSaveZonesToConfig(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skipf("tzdata not available: %v", err)
+	}
+	zones := []Zone{{Name: "Tokyo", Location: tokyo}}
+
+	tests := []struct {
+		name      string
+		existing  string
+		want      []string
+		wantError bool
+	}{
+		{
+			name: "no file",
+			want: []string{"zones:", "name: Tokyo", "location: Asia/Tokyo"},
+		},
+		{
+			name: "keeps other settings and comments",
+			existing: "# my settings\nzones:\n  - name: Old\n    location: Europe/Berlin\n" +
+				"format:\n  clock: 24h # always\nworking_hours:\n  default: 8-16\n",
+			want: []string{"# my settings", "clock: 24h # always", "default: 8-16", "name: Tokyo"},
+		},
+		{
+			name:     "adds zones",
+			existing: "rollover: true\n",
+			want:     []string{"rollover: true", "name: Tokyo"},
+		},
+		{
+			name:      "invalid file",
+			existing:  "format:\n  clock: [24h\n",
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			home := t.TempDir()
+			t.Setenv("HOME", home)
+			path := filepath.Join(home, ".aeon.yaml")
+			if tt.existing != "" {
+				if err := os.WriteFile(path, []byte(tt.existing), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			err := saveZonesToConfig(zones)
+			data, _ := os.ReadFile(path)
+			if tt.wantError {
+				if err == nil {
+					t.Errorf("saveZonesToConfig succeeded, want error")
+				}
+				if string(data) != tt.existing {
+					t.Errorf("config file changed to %q", data)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("saveZonesToConfig error: %v", err)
+			}
+
+			for _, want := range tt.want {
+				if !strings.Contains(string(data), want) {
+					t.Errorf("config file %q is missing %q", data, want)
+				}
+			}
+			if strings.Contains(string(data), "Old") {
+				t.Errorf("config file %q kept the old zones", data)
+			}
+
+			config, err := loadConfig()
+			if err != nil {
+				t.Fatalf("loadConfig error: %v", err)
+			}
+			if len(config.Zones) != 1 || config.Zones[0].Location != "Asia/Tokyo" {
+				t.Errorf("loadConfig zones = %+v", config.Zones)
+			}
+		})
+	}
+}
+
+func TestLoadConfigInvalid(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	if _, err := loadConfig(); err != nil {
+		t.Errorf("loadConfig without a file: %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(home, ".aeon.yaml"), []byte("zones: {oops"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadConfig(); err == nil {
+		t.Errorf("loadConfig of an invalid file succeeded, want error")
+	}
+
+	// Bad sleep hours are reported, but the rest of the config is kept
+	if err := os.WriteFile(filepath.Join(home, ".aeon.yaml"), []byte("rollover: true\nmeeting:\n  sleep_hours: late\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	config, err := loadConfig()
+	if err == nil {
+		t.Errorf("loadConfig with invalid sleep hours succeeded, want error")
+	}
+	if !config.Rollover {
+		t.Errorf("loadConfig with invalid sleep hours dropped the other settings")
+	}
+	if got := config.Meeting.sleepHours(); len(got) != 1 || got[0] != defaultSleepHours[0] {
+		t.Errorf("sleepHours() = %v, want the default", got)
+	}
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// wallClock describes how a local wall-clock time maps onto real instants in
+// a zone. Around DST transitions a wall time can be skipped (gap) or repeated
+// (overlap), which time.Date silently papers over.
+type wallClock struct {
+	// Wall is the wall time asked for
+	Wall time.Time
+	// Instants holds the matching instants in chronological order: one
+	// normally, two when a fall-back transition repeats the wall time
+	Instants []time.Time
+	// Gap is set when a spring-forward transition skips the wall time.
+	// Instants then holds the wall time shifted forward by the gap, so
+	// 02:30 on a spring-forward day becomes 03:30.
+	Gap bool
+}
+
+// Overlap reports whether the wall time occurs twice
+func (w wallClock) Overlap() bool {
+	return len(w.Instants) > 1
+}
+
+// resolveWallClock places the wall-clock fields of wall in loc, detecting
+// DST gaps and overlaps
+func resolveWallClock(wall time.Time, loc *time.Location) wallClock {
+	year, month, day := wall.Date()
+	hour, minute, sec := wall.Clock()
+	nsec := wall.Nanosecond()
+
+	naive := time.Date(year, month, day, hour, minute, sec, nsec, time.UTC)
+	guess := time.Date(year, month, day, hour, minute, sec, nsec, loc)
+
+	// Any instant matching the wall time must use one of the offsets in
+	// effect around it
+	seenOffsets := map[int]bool{}
+	var instants []time.Time
+	for _, probe := range []time.Time{guess.Add(-24 * time.Hour), guess, guess.Add(24 * time.Hour)} {
+		_, offset := probe.Zone()
+		if seenOffsets[offset] {
+			continue
+		}
+		seenOffsets[offset] = true
+
+		candidate := naive.Add(-time.Duration(offset) * time.Second).In(loc)
+		if sameWallClock(candidate, naive) {
+			instants = append(instants, candidate)
+		}
+	}
+
+	if len(instants) == 0 {
+		// Read the wall time with the offset from before the transition,
+		// which lands the same distance past it
+		_, before := guess.Add(-24 * time.Hour).Zone()
+		shifted := naive.Add(-time.Duration(before) * time.Second).In(loc)
+		return wallClock{Wall: wall, Instants: []time.Time{shifted}, Gap: true}
+	}
+
+	sort.Slice(instants, func(i, j int) bool {
+		return instants[i].Before(instants[j])
+	})
+	return wallClock{Wall: wall, Instants: instants}
+}
+
+// dayBounds returns the instants at which the calendar day of wall starts
+// and ends in loc. Days around DST transitions are 23 or 25 hours long.
+func dayBounds(wall time.Time, loc *time.Location) (time.Time, time.Time) {
+	midnight := time.Date(wall.Year(), wall.Month(), wall.Day(), 0, 0, 0, 0, time.UTC)
+	start := resolveWallClock(midnight, loc).Instants[0]
+	end := resolveWallClock(midnight.AddDate(0, 0, 1), loc).Instants[0]
+	return start, end
+}
+
+// sameWallClock reports whether a and b show the same date and time of day,
+// ignoring their zones
+func sameWallClock(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return ay == by && am == bm && ad == bd &&
+		a.Hour() == b.Hour() && a.Minute() == b.Minute() &&
+		a.Second() == b.Second() && a.Nanosecond() == b.Nanosecond()
+}
+
+// floatingTime copies the wall clock of t into a zero-offset zone that keeps
+// the name of t's zone. Parsing against it yields the wall time the user
+// asked for, untouched by DST normalization, while zone-aware rules such as
+// weekends still see the original zone name.
+func floatingTime(t time.Time) time.Time {
+	floating := time.FixedZone(t.Location().String(), 0)
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), floating)
+}
+
+// dstTransition is a change of a zone's UTC offset
+type dstTransition struct {
+	// At is the first instant with the new offset
+	At time.Time
+	// Shift is how far clocks move: +1h when they spring forward
+	Shift time.Duration
+}
+
+// nearestTransition finds the offset change in loc closest to t, looking
+// up to window before and after it
+func nearestTransition(t time.Time, loc *time.Location, window time.Duration) (dstTransition, bool) {
+	if window <= 0 {
+		return dstTransition{}, false
+	}
+
+	var nearest dstTransition
+	found := false
+	for _, span := range [][2]time.Time{{t, t.Add(window)}, {t.Add(-window), t}} {
+		lo, hi := span[0].In(loc), span[1].In(loc)
+		_, before := lo.Zone()
+		_, after := hi.Zone()
+		if before == after {
+			continue
+		}
+
+		// Narrow down to the second the offset changes
+		for hi.Sub(lo) > time.Second {
+			mid := lo.Add(hi.Sub(lo) / 2)
+			if _, offset := mid.Zone(); offset == before {
+				lo = mid
+			} else {
+				hi = mid
+			}
+		}
+		at := hi.Truncate(time.Second)
+
+		if !found || absDuration(at.Sub(t)) < absDuration(nearest.At.Sub(t)) {
+			nearest = dstTransition{At: at, Shift: time.Duration(after-before) * time.Second}
+			found = true
+		}
+	}
+
+	return nearest, found
+}
+
+// describeTransition explains a DST change in a zone relative to ref:
+// "Clocks in Berlin go forward 1h on Sun Mar 29, 10 days after this time"
+func describeTransition(transition dstTransition, zone string, ref time.Time, f timeFormatter) string {
+	after := transition.At.After(ref)
+
+	verb := "go"
+	if !after {
+		verb = "went"
+	}
+	direction := "forward"
+	if transition.Shift < 0 {
+		direction = "back"
+	}
+
+	var when string
+	days := calendarDays(ref.In(transition.At.Location()), transition.At)
+	switch {
+	case days == 0 && after:
+		when = "later the same day"
+	case days == 0:
+		when = "earlier the same day"
+	case days > 0:
+		when = pluralize(days, "day") + " after this time"
+	default:
+		when = pluralize(-days, "day") + " before this time"
+	}
+
+	return fmt.Sprintf("Clocks in %s %s %s %s on %s, %s",
+		zone, verb, direction,
+		strings.TrimPrefix(formatOffsetDifference(absDuration(transition.Shift)), "+"),
+		f.ShortDate(transition.At),
+		when,
+	)
+}
+
+// absDuration returns the absolute value of d
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestResolveWallClock(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("tzdata not available: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		wall        time.Time
+		wantGap     bool
+		wantOffsets []int // UTC offsets in hours, in chronological order
+		wantShifted string
+	}{
+		{
+			name:        "ordinary time",
+			wall:        time.Date(2026, 1, 16, 14, 30, 0, 0, time.UTC),
+			wantOffsets: []int{-5},
+		},
+		{
+			name:        "spring forward gap",
+			wall:        time.Date(2026, 3, 8, 2, 30, 0, 0, time.UTC),
+			wantGap:     true,
+			wantOffsets: []int{-4},
+			wantShifted: "03:30",
+		},
+		{
+			name:        "fall back overlap",
+			wall:        time.Date(2026, 11, 1, 1, 30, 0, 0, time.UTC),
+			wantOffsets: []int{-4, -5},
+		},
+		{
+			name:        "just after fall back",
+			wall:        time.Date(2026, 11, 1, 2, 0, 0, 0, time.UTC),
+			wantOffsets: []int{-5},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := resolveWallClock(tt.wall, newYork)
+
+			if result.Gap != tt.wantGap {
+				t.Errorf("gap = %v, want %v", result.Gap, tt.wantGap)
+			}
+			if len(result.Instants) != len(tt.wantOffsets) {
+				t.Fatalf("got %d instants, want %d", len(result.Instants), len(tt.wantOffsets))
+			}
+			for i, instant := range result.Instants {
+				_, offset := instant.Zone()
+				if offset != tt.wantOffsets[i]*3600 {
+					t.Errorf("instant %d offset = %d, want %dh", i, offset, tt.wantOffsets[i])
+				}
+				if tt.wantGap && instant.Format("15:04") != tt.wantShifted {
+					t.Errorf("gap instant = %s, want %s", instant.Format("15:04"), tt.wantShifted)
+				}
+				if !tt.wantGap && (instant.Hour() != tt.wall.Hour() || instant.Minute() != tt.wall.Minute()) {
+					t.Errorf("instant %d wall clock = %s, want %s", i, instant.Format("15:04"), tt.wall.Format("15:04"))
+				}
+			}
+		})
+	}
+}
+
+func TestFloatingTimeKeepsZoneName(t *testing.T) {
+	riyadh, err := time.LoadLocation("Asia/Riyadh")
+	if err != nil {
+		t.Skipf("tzdata not available: %v", err)
+	}
+
+	ref := time.Date(2026, 1, 15, 14, 30, 0, 0, riyadh) // Thursday
+	result, err := parseTimeWithContext("next business day 9am", floatingTime(ref))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Time.Day() != 18 {
+		t.Errorf("day = %d, want 18 (Sunday is a working day in Riyadh)", result.Time.Day())
+	}
+}
+
+func TestDayBounds(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("tzdata not available: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		wall       time.Time
+		wantLength time.Duration
+	}{
+		{"ordinary day", time.Date(2026, 1, 20, 9, 0, 0, 0, time.UTC), 24 * time.Hour},
+		{"spring forward", time.Date(2026, 3, 8, 9, 0, 0, 0, time.UTC), 23 * time.Hour},
+		{"fall back", time.Date(2026, 11, 1, 9, 0, 0, 0, time.UTC), 25 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end := dayBounds(tt.wall, newYork)
+
+			if start.Hour() != 0 || start.Day() != tt.wall.Day() {
+				t.Errorf("start = %v, want midnight on day %d", start, tt.wall.Day())
+			}
+			if got := end.Sub(start); got != tt.wantLength {
+				t.Errorf("length = %v, want %v", got, tt.wantLength)
+			}
+		})
+	}
+}
+
+func TestNearestTransition(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("tzdata not available: %v", err)
+	}
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+
+	week := 7 * 24 * time.Hour
+	springForward := time.Date(2026, 3, 8, 7, 0, 0, 0, time.UTC)
+	fallBack := time.Date(2026, 11, 1, 6, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		t         time.Time
+		loc       *time.Location
+		wantFound bool
+		wantAt    time.Time
+		wantShift time.Duration
+		wantText  string
+	}{
+		{
+			name:      "spring forward ahead",
+			t:         time.Date(2026, 3, 5, 15, 0, 0, 0, newYork),
+			loc:       newYork,
+			wantFound: true,
+			wantAt:    springForward,
+			wantShift: time.Hour,
+			wantText:  "Clocks in NYC go forward 1h on Sun Mar 08, 3 days after this time",
+		},
+		{
+			name:      "fall back behind",
+			t:         time.Date(2026, 11, 3, 9, 0, 0, 0, newYork),
+			loc:       newYork,
+			wantFound: true,
+			wantAt:    fallBack,
+			wantShift: -time.Hour,
+			wantText:  "Clocks in NYC went back 1h on Sun Nov 01, 2 days before this time",
+		},
+		{
+			name: "outside the window",
+			t:    time.Date(2026, 1, 20, 9, 0, 0, 0, newYork),
+			loc:  newYork,
+		},
+		{
+			name: "zone without DST",
+			t:    time.Date(2026, 3, 5, 15, 0, 0, 0, tokyo),
+			loc:  tokyo,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transition, found := nearestTransition(tt.t, tt.loc, week)
+			if found != tt.wantFound {
+				t.Fatalf("found = %v, want %v", found, tt.wantFound)
+			}
+			if !found {
+				return
+			}
+
+			if !transition.At.Equal(tt.wantAt) {
+				t.Errorf("at = %v, want %v", transition.At, tt.wantAt)
+			}
+			if transition.Shift != tt.wantShift {
+				t.Errorf("shift = %v, want %v", transition.Shift, tt.wantShift)
+			}
+			if got := describeTransition(transition, "NYC", tt.t, defaultFormatter); got != tt.wantText {
+				t.Errorf("description = %q, want %q", got, tt.wantText)
+			}
+		})
+	}
+}
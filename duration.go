@@ -0,0 +1,285 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// durationQuery is a "how long until" or "time between" query
+type durationQuery struct {
+	From queryEndpoint
+	To   queryEndpoint
+	// DaysOnly counts calendar days instead of elapsed time: "days until"
+	DaysOnly bool
+	// Until is set for queries measured from now to a future time
+	Until bool
+}
+
+// queryEndpoint is one side of a duration query
+type queryEndpoint struct {
+	TimeExpr string
+	Zone     string
+	Loc      *time.Location
+	Offsets  []timeOffset
+}
+
+// nowEndpoint is the implicit start of "how long until" queries
+var nowEndpoint = queryEndpoint{TimeExpr: "now", Zone: localZoneName, Loc: time.Local}
+
+// untilPrefixes start queries measured from now
+var untilPrefixes = [][]string{
+	{"how", "long", "until"},
+	{"how", "long", "till"},
+	{"how", "long", "to"},
+	{"time", "until"},
+	{"time", "till"},
+	{"hours", "until"},
+}
+
+// betweenPrefixes start queries measured between two times joined by "and"
+var betweenPrefixes = [][]string{
+	{"time", "between"},
+	{"how", "long", "between"},
+	{"hours", "between"},
+}
+
+// parseDurationQuery recognizes duration queries:
+// - "how long until 9am Tokyo"
+// - "time between 3pm NYC and 9am tomorrow Sydney"
+// - "days until 2027-01-01 in Auckland"
+//
+// The boolean reports whether input is a duration query at all, so callers
+// can fall back to conversion parsing.
+func parseDurationQuery(input string) (durationQuery, bool, error) {
+	tokens := tokenizeQuery(input)
+
+	if hasPrefixWords(tokens, []string{"days", "until"}) || hasPrefixWords(tokens, []string{"days", "till"}) {
+		to, err := matchEndpoint(tokens[2:])
+		return durationQuery{From: nowEndpoint, To: to, DaysOnly: true, Until: true}, true, err
+	}
+
+	for _, prefix := range untilPrefixes {
+		if hasPrefixWords(tokens, prefix) {
+			to, err := matchEndpoint(tokens[len(prefix):])
+			return durationQuery{From: nowEndpoint, To: to, Until: true}, true, err
+		}
+	}
+
+	for _, prefix := range betweenPrefixes {
+		if !hasPrefixWords(tokens, prefix) {
+			continue
+		}
+
+		rest := tokens[len(prefix):]
+		for k, t := range rest {
+			if !strings.EqualFold(t.Text, "and") {
+				continue
+			}
+			from, fromErr := matchEndpoint(rest[:k])
+			to, toErr := matchEndpoint(rest[k+1:])
+			if fromErr == nil && toErr == nil {
+				return durationQuery{From: from, To: to}, true, nil
+			}
+		}
+		return durationQuery{}, true, fmt.Errorf("Use format 'time between 3pm NYC and 9am tomorrow Sydney'")
+	}
+
+	return durationQuery{}, false, nil
+}
+
+// matchEndpoint splits "[time] [in] [zone]" into its parts. The zone is
+// optional and defaults to the local zone.
+func matchEndpoint(tokens []token) (queryEndpoint, error) {
+	tokens, offsets, ok := splitOffsets(tokens)
+	if !ok {
+		return queryEndpoint{}, fmt.Errorf("Write offsets like '+ 90m', '- 6h' or '+ 2 business days'")
+	}
+
+	tokens = trimTimeFiller(tokens)
+	if len(tokens) == 0 {
+		return queryEndpoint{}, fmt.Errorf("Specify a time")
+	}
+
+	// Longest zone span first so "New York" beats "York"
+	for i := 1; i < len(tokens); i++ {
+		loc, ok := lookupZone(tokens[i:])
+		if !ok {
+			continue
+		}
+
+		timeTokens := tokens[:i]
+		if last := timeTokens[len(timeTokens)-1]; strings.EqualFold(last.Text, "in") && len(timeTokens) > 1 {
+			timeTokens = timeTokens[:len(timeTokens)-1]
+		}
+
+		return queryEndpoint{
+			TimeExpr: joinTokens(timeTokens),
+			Zone:     zoneDisplayName(joinTokens(tokens[i:])),
+			Loc:      loc,
+			Offsets:  offsets,
+		}, nil
+	}
+
+	return queryEndpoint{
+		TimeExpr: joinTokens(tokens),
+		Zone:     localZoneName,
+		Loc:      time.Local,
+		Offsets:  offsets,
+	}, nil
+}
+
+// durationResult is a computed duration query
+type durationResult struct {
+	From time.Time
+	To   time.Time
+	// Elapsed is the real time between From and To, which differs from the
+	// wall-clock difference when a DST transition falls in between
+	Elapsed time.Duration
+	// Days is the number of calendar days from From's date to To's date, in
+	// To's zone
+	Days int
+}
+
+// computeDuration resolves both endpoints of a query against now
+func computeDuration(query durationQuery, now time.Time, opts parseOptions) (durationResult, error) {
+	from, err := resolveTimeExpr(query.From.TimeExpr, query.From.Loc, query.From.Offsets, now, opts)
+	if err != nil {
+		return durationResult{}, err
+	}
+
+	// "how long until 9am" always means the next 9am
+	toOpts := opts
+	if query.Until {
+		toOpts.Rollover = true
+	}
+	to, err := resolveTimeExpr(query.To.TimeExpr, query.To.Loc, query.To.Offsets, now, toOpts)
+	if err != nil {
+		return durationResult{}, err
+	}
+
+	return durationResult{
+		From:    from.Time,
+		To:      to.Time,
+		Elapsed: to.Time.Sub(from.Time),
+		// Count calendar days in the target zone
+		Days: calendarDays(from.Time.In(query.To.Loc), to.Time),
+	}, nil
+}
+
+// calendarDays returns the number of days from the date of from to the date
+// of to, each read in its own zone
+func calendarDays(from, to time.Time) int {
+	// Compare at noon UTC to stay clear of DST transitions
+	fromNoon := time.Date(from.Year(), from.Month(), from.Day(), 12, 0, 0, 0, time.UTC)
+	toNoon := time.Date(to.Year(), to.Month(), to.Day(), 12, 0, 0, 0, time.UTC)
+	return int(toNoon.Sub(fromNoon).Hours() / 24)
+}
+
+// formatHumanDuration renders a duration as "2 days, 3 hours, 15 minutes"
+func formatHumanDuration(d time.Duration) string {
+	suffix := ""
+	if d < 0 {
+		d = -d
+		suffix = " ago"
+	}
+
+	d = d.Round(time.Minute)
+	days := int(d / (24 * time.Hour))
+	hours := int(d % (24 * time.Hour) / time.Hour)
+	minutes := int(d % time.Hour / time.Minute)
+
+	var parts []string
+	if days > 0 {
+		parts = append(parts, pluralize(days, "day"))
+	}
+	if hours > 0 {
+		parts = append(parts, pluralize(hours, "hour"))
+	}
+	if minutes > 0 || len(parts) == 0 {
+		parts = append(parts, pluralize(minutes, "minute"))
+	}
+
+	return strings.Join(parts, ", ") + suffix
+}
+
+// formatISODuration renders a duration in ISO 8601 form: "PT51H15M". Hours
+// are not folded into days, since a day is not always 24 hours.
+func formatISODuration(d time.Duration) string {
+	sign := ""
+	if d < 0 {
+		d = -d
+		sign = "-"
+	}
+
+	d = d.Round(time.Second)
+	hours := int(d / time.Hour)
+	minutes := int(d % time.Hour / time.Minute)
+	seconds := int(d % time.Minute / time.Second)
+
+	var b strings.Builder
+	b.WriteString(sign + "PT")
+	if hours > 0 {
+		fmt.Fprintf(&b, "%dH", hours)
+	}
+	if minutes > 0 {
+		fmt.Fprintf(&b, "%dM", minutes)
+	}
+	if seconds > 0 || (hours == 0 && minutes == 0) {
+		fmt.Fprintf(&b, "%dS", seconds)
+	}
+	return b.String()
+}
+
+// pluralize renders a count with its unit: "1 day", "3 days"
+func pluralize(n int, unit string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, unit)
+	}
+	return fmt.Sprintf("%d %ss", n, unit)
+}
+
+// dstShift returns how far loc's UTC offset moves between from and to
+func dstShift(from, to time.Time, loc *time.Location) time.Duration {
+	_, before := from.In(loc).Zone()
+	_, after := to.In(loc).Zone()
+	return time.Duration(after-before) * time.Second
+}
+
+// processDuration renders a duration query for the Convert view
+func (m model) processDuration(query durationQuery) (string, error) {
+	result, err := computeDuration(query, time.Now(), m.parseOpts)
+	if err != nil {
+		return "", err
+	}
+
+	if query.DaysOnly {
+		return fmt.Sprintf("%s until %s in %s\n  P%dD",
+			pluralize(result.Days, "day"),
+			m.format.Date(result.To),
+			query.To.Zone,
+			result.Days,
+		), nil
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "From  %s in %s\n", m.format.DateTime(result.From), query.From.Zone)
+	fmt.Fprintf(&b, "To    %s in %s\n\n", m.format.DateTime(result.To), query.To.Zone)
+	fmt.Fprintf(&b, "  %s\n  %s", formatHumanDuration(result.Elapsed), formatISODuration(result.Elapsed))
+
+	// Point out DST changes, since elapsed time then differs from the
+	// difference in wall-clock time
+	seen := map[string]bool{}
+	for _, endpoint := range []queryEndpoint{query.From, query.To} {
+		if seen[endpoint.Loc.String()] {
+			continue
+		}
+		seen[endpoint.Loc.String()] = true
+
+		if shift := dstShift(result.From, result.To, endpoint.Loc); shift != 0 {
+			fmt.Fprintf(&b, "\n\n⚠️  Clocks in %s change by %+.0fh in between", endpoint.Zone, shift.Hours())
+		}
+	}
+
+	return b.String(), nil
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestComputeDuration(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("tzdata not available: %v", err)
+	}
+
+	// Friday, January 16, 2026 at 2:30 PM in New York
+	now := time.Date(2026, 1, 16, 14, 30, 0, 0, newYork)
+
+	tests := []struct {
+		name        string
+		input       string
+		wantElapsed time.Duration
+		wantDays    int
+		wantError   bool
+	}{
+		{
+			name:        "how long until",
+			input:       "how long until 5pm NYC",
+			wantElapsed: 2*time.Hour + 30*time.Minute,
+		},
+		{
+			name:        "how long until in another zone",
+			input:       "how long until 9am Tokyo",
+			wantElapsed: 4*time.Hour + 30*time.Minute, // it is already Saturday in Tokyo
+		},
+		{
+			name:        "time between",
+			input:       "time between 3pm NYC and 9am tomorrow Tokyo",
+			wantElapsed: 28 * time.Hour,
+		},
+		{
+			name:        "time between across spring forward",
+			input:       "time between 2026-03-07 9am NYC and 2026-03-08 9am NYC",
+			wantElapsed: 23 * time.Hour,
+		},
+		{
+			name:     "days until",
+			input:    "days until 2027-01-01 in Auckland",
+			wantDays: 349, // counted from Saturday in Auckland
+		},
+		{
+			name:      "between without and",
+			input:     "time between 3pm NYC",
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, ok, err := parseDurationQuery(tt.input)
+			if !ok {
+				t.Fatalf("not recognized as a duration query")
+			}
+
+			if tt.wantError {
+				if err == nil {
+					t.Errorf("expected error but got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			// Anchor "now" endpoints in New York so the test is independent
+			// of the machine's zone
+			if query.From.Loc == time.Local {
+				query.From.Loc = newYork
+			}
+
+			result, err := computeDuration(query, now, parseOptions{})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if query.DaysOnly {
+				if result.Days != tt.wantDays {
+					t.Errorf("days = %d, want %d", result.Days, tt.wantDays)
+				}
+				return
+			}
+			if result.Elapsed != tt.wantElapsed {
+				t.Errorf("elapsed = %v, want %v", result.Elapsed, tt.wantElapsed)
+			}
+		})
+	}
+}
+
+func TestParseDurationQueryIgnoresConversions(t *testing.T) {
+	if _, ok, _ := parseDurationQuery("3pm NYC to Berlin"); ok {
+		t.Errorf("conversion query recognized as a duration query")
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		duration  time.Duration
+		wantHuman string
+		wantISO   string
+	}{
+		{51*time.Hour + 15*time.Minute, "2 days, 3 hours, 15 minutes", "PT51H15M"},
+		{time.Hour, "1 hour", "PT1H"},
+		{0, "0 minutes", "PT0S"},
+		{-90 * time.Minute, "1 hour, 30 minutes ago", "-PT1H30M"},
+	}
+
+	for _, tt := range tests {
+		if got := formatHumanDuration(tt.duration); got != tt.wantHuman {
+			t.Errorf("formatHumanDuration(%v) = %q, want %q", tt.duration, got, tt.wantHuman)
+		}
+		if got := formatISODuration(tt.duration); got != tt.wantISO {
+			t.Errorf("formatISODuration(%v) = %q, want %q", tt.duration, got, tt.wantISO)
+		}
+	}
+}
+
+func TestCalendarDays(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skipf("tzdata not available: %v", err)
+	}
+	newYork, _ := time.LoadLocation("America/New_York")
+
+	from := time.Date(2026, 1, 19, 15, 0, 0, 0, newYork)
+
+	tests := []struct {
+		name string
+		to   time.Time
+		want int
+	}{
+		{"same instant next day in Tokyo", from.In(tokyo), 1},
+		{"same day", from.Add(8 * time.Hour), 0},
+		{"day before", time.Date(2026, 1, 18, 23, 0, 0, 0, tokyo), -1},
+	}
+
+	for _, tt := range tests {
+		if got := calendarDays(from, tt.to); got != tt.want {
+			t.Errorf("%s: calendarDays = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,238 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// MeetingConfig is the "meeting" section of the config file. It sets how
+// slots are ranked when working hours don't overlap.
+type MeetingConfig struct {
+	// Suggestions is how many ranked slots to show, 3 by default
+	Suggestions int `yaml:"suggestions,omitempty"`
+	// OffHoursCost is the cost of an hour outside working hours, 1 by
+	// default
+	OffHoursCost float64 `yaml:"off_hours_cost,omitempty"`
+	// NightCost is the cost of an hour during sleep hours, 3 by default
+	NightCost float64 `yaml:"night_cost,omitempty"`
+	// SleepHours is when participants are assumed to be asleep, written
+	// like working hours: "23-7" by default
+	SleepHours string `yaml:"sleep_hours,omitempty"`
+	// ExportDir is where rotations are saved as calendar files, aeon's
+	// config directory by default
+	ExportDir string `yaml:"export_dir,omitempty"`
+	// Weights scale the cost for a participant, by the name used in
+	// queries: "Tokyo: 2" counts Tokyo's inconvenience double
+	Weights map[string]float64 `yaml:"weights,omitempty"`
+}
+
+// defaultMeetingLength is the length of suggested slots
+const defaultMeetingLength = time.Hour
+
+// suggestions returns how many ranked slots to show
+func (c MeetingConfig) suggestions() int {
+	if c.Suggestions <= 0 {
+		return 3
+	}
+	return c.Suggestions
+}
+
+// offHoursCost returns the cost of an hour outside working hours
+func (c MeetingConfig) offHoursCost() float64 {
+	if c.OffHoursCost <= 0 {
+		return 1
+	}
+	return c.OffHoursCost
+}
+
+// nightCost returns the cost of an hour during sleep hours
+func (c MeetingConfig) nightCost() float64 {
+	if c.NightCost <= 0 {
+		return 3
+	}
+	return c.NightCost
+}
+
+// defaultSleepHours is 11 PM to 7 AM
+var defaultSleepHours = workingHours{{Start: 23 * time.Hour, End: 31 * time.Hour}}
+
+// sleepHours returns the configured sleep hours, or 11 PM to 7 AM when
+// none are set or they don't parse
+func (c MeetingConfig) sleepHours() workingHours {
+	if c.SleepHours == "" {
+		return defaultSleepHours
+	}
+	hours, err := parseWorkingHours(c.SleepHours)
+	if err != nil {
+		return defaultSleepHours
+	}
+	return hours
+}
+
+// weight returns the weight of a participant, ignoring case, or 1
+func (c MeetingConfig) weight(name string) float64 {
+	for configured, weight := range c.Weights {
+		if strings.EqualFold(configured, name) {
+			return weight
+		}
+	}
+	return 1
+}
+
+// inconvenience is what a slot costs one participant
+type inconvenience struct {
+	// OffHours is the time outside working hours but not at night
+	OffHours time.Duration
+	// Night is the time during sleep hours
+	Night time.Duration
+	// Cost is the weighted score
+	Cost float64
+}
+
+// suggestion is a candidate meeting slot with its cost to everyone
+type suggestion struct {
+	Slot  interval
+	Costs []inconvenience
+	// Total is the sum of the participants' costs and Worst the highest
+	Total float64
+	Worst float64
+}
+
+// slotStep is how often candidate slots start, and the resolution at which
+// their cost is measured
+const (
+	slotStep    = 30 * time.Minute
+	costSegment = 15 * time.Minute
+)
+
+// suggestSlots ranks slots of the given length starting within any of the
+// ranges, cheapest first. Ties go to the slot whose worst-off participant is
+// better off, then to the earlier slot. Overlapping slots are left out in
+// favor of the better one, so each suggestion is a real alternative.
+func suggestSlots(participants []participant, ranges []interval, length time.Duration, config MeetingConfig) []suggestion {
+	var candidates []suggestion
+	for _, r := range ranges {
+		candidates = append(candidates, scoreSlots(participants, r.Start, r.End, length, config)...)
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Total != candidates[j].Total {
+			return candidates[i].Total < candidates[j].Total
+		}
+		return candidates[i].Worst < candidates[j].Worst
+	})
+
+	var chosen []suggestion
+	for _, candidate := range candidates {
+		if !intersectsSpan(slotsOf(chosen), candidate.Slot) {
+			chosen = append(chosen, candidate)
+		}
+	}
+	return chosen
+}
+
+// scoreSlots returns every slot of the given length starting within
+// from..to, at slotStep apart, with what it costs each participant
+func scoreSlots(participants []participant, from, to time.Time, length time.Duration, config MeetingConfig) []suggestion {
+	end := to.Add(length)
+	sleepHours := config.sleepHours()
+	work := make([][]interval, len(participants))
+	sleep := make([][]interval, len(participants))
+	for i, p := range participants {
+		work[i] = workingWindows(p, from, end)
+		sleep[i] = workingWindows(participant{Loc: p.Loc, Hours: sleepHours}, from, end)
+	}
+
+	var candidates []suggestion
+	for start := from; start.Before(to); start = start.Add(slotStep) {
+		s := suggestion{Slot: interval{Start: start, End: start.Add(length)}}
+		for i, p := range participants {
+			var cost inconvenience
+			for t := start; t.Before(s.Slot.End); t = t.Add(costSegment) {
+				segment := min(costSegment, s.Slot.End.Sub(t))
+				middle := t.Add(segment / 2)
+				switch {
+				case containsInstant(work[i], middle):
+				case containsInstant(sleep[i], middle):
+					cost.Night += segment
+				default:
+					cost.OffHours += segment
+				}
+			}
+			cost.Cost = config.weight(p.Name) *
+				(cost.OffHours.Hours()*config.offHoursCost() + cost.Night.Hours()*config.nightCost())
+
+			s.Costs = append(s.Costs, cost)
+			s.Total += cost.Cost
+			s.Worst = max(s.Worst, cost.Cost)
+		}
+		candidates = append(candidates, s)
+	}
+	return candidates
+}
+
+// slotsOf returns the slots of the suggestions
+func slotsOf(suggestions []suggestion) []interval {
+	slots := make([]interval, len(suggestions))
+	for i, s := range suggestions {
+		slots[i] = s.Slot
+	}
+	return slots
+}
+
+// describeInconvenience explains a participant's cost: "working", or
+// "1h outside working hours, 30m at night (cost 2.5)"
+func describeInconvenience(cost inconvenience, weight float64) string {
+	var parts []string
+	if cost.OffHours > 0 {
+		parts = append(parts, formatShortDuration(cost.OffHours)+" outside working hours")
+	}
+	if cost.Night > 0 {
+		parts = append(parts, formatShortDuration(cost.Night)+" at night")
+	}
+	if len(parts) == 0 {
+		return "working"
+	}
+
+	text := strings.Join(parts, ", ")
+	if weight != 1 {
+		return fmt.Sprintf("%s (cost %.1f, weight %g)", text, cost.Cost, weight)
+	}
+	return fmt.Sprintf("%s (cost %.1f)", text, cost.Cost)
+}
+
+// formatShortDuration renders a duration compactly: "1h", "30m", "1h30m"
+func formatShortDuration(d time.Duration) string {
+	text := ""
+	if hours := int(d / time.Hour); hours > 0 {
+		text = fmt.Sprintf("%dh", hours)
+	}
+	if minutes := int(d % time.Hour / time.Minute); minutes > 0 || text == "" {
+		text += fmt.Sprintf("%dm", minutes)
+	}
+	return text
+}
+
+// renderSuggestions lists the top suggestions with each participant's time
+// and what the slot costs them
+func (m model) renderSuggestions(participants []participant, suggestions []suggestion) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Least inconvenient times (an hour outside working hours costs %g, at night %g):\n",
+		m.meetingConfig.offHoursCost(), m.meetingConfig.nightCost())
+
+	for n, s := range suggestions[:min(len(suggestions), m.meetingConfig.suggestions())] {
+		local := s.Slot.Start.In(time.Local)
+		fmt.Fprintf(&b, "\n  %d. %s - %s your time  •  cost %.1f\n", n+1,
+			m.format.Time(local), m.format.Time(s.Slot.End.In(time.Local)), s.Total)
+
+		rows := make([][]string, len(participants))
+		for i, p := range participants {
+			row := m.meetingRow(p.Name, s.Slot, p.Loc)
+			rows[i] = append(row, describeInconvenience(s.Costs[i], m.meetingConfig.weight(p.Name)))
+		}
+		b.WriteString(formatTable(rows, "       "))
+		b.WriteString("\n")
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSuggestSlots(t *testing.T) {
+	load := func(name, label string) participant {
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			t.Fatalf("LoadLocation(%q): %v", name, err)
+		}
+		return participant{Name: label, Loc: loc, Hours: defaultWorkingHours}
+	}
+	participants := []participant{
+		load("America/New_York", "NYC"),
+		load("Europe/London", "London"),
+		load("Asia/Tokyo", "Tokyo"),
+	}
+	day := time.Date(2026, 1, 20, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		config    MeetingConfig
+		wantSlots []string
+		wantTotal float64
+	}{
+		{
+			// An hour outside working hours in NYC and Tokyo beats an hour
+			// of Tokyo's night
+			name:      "default costs",
+			wantSlots: []string{"12:00", "13:00"},
+			wantTotal: 2,
+		},
+		{
+			// Tokyo's evening now costs as much as a night elsewhere, so
+			// the meeting moves into Tokyo's working day
+			name:      "weighted",
+			config:    MeetingConfig{Weights: map[string]float64{"tokyo": 3}},
+			wantSlots: []string{"00:00"},
+			wantTotal: 4,
+		},
+		{
+			name:      "cheap nights",
+			config:    MeetingConfig{NightCost: 0.5},
+			wantSlots: []string{"14:00"},
+			wantTotal: 0.5,
+		},
+		{
+			// With nights ending at 6, Tokyo's 11 PM is only off hours
+			name:      "short nights",
+			config:    MeetingConfig{SleepHours: "0-6"},
+			wantSlots: []string{"14:00"},
+			wantTotal: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suggestions := suggestSlots(participants, []interval{{Start: day, End: day.Add(24 * time.Hour)}}, time.Hour, tt.config)
+			if len(suggestions) < len(tt.wantSlots) {
+				t.Fatalf("suggestSlots() returned %d suggestions, want at least %d", len(suggestions), len(tt.wantSlots))
+			}
+			for i, want := range tt.wantSlots {
+				if got := suggestions[i].Slot.Start.UTC().Format("15:04"); got != want {
+					t.Errorf("suggestion %d starts at %s, want %s", i+1, got, want)
+				}
+			}
+			if suggestions[0].Total != tt.wantTotal {
+				t.Errorf("best suggestion costs %g, want %g", suggestions[0].Total, tt.wantTotal)
+			}
+
+			// Suggestions are alternatives, never overlapping each other
+			for i := 1; i < len(suggestions); i++ {
+				if intersectsSpan(slotsOf(suggestions[:i]), suggestions[i].Slot) {
+					t.Errorf("suggestion %d overlaps an earlier one", i+1)
+				}
+			}
+		})
+	}
+}
+
+func TestDescribeInconvenience(t *testing.T) {
+	tests := []struct {
+		cost   inconvenience
+		weight float64
+		want   string
+	}{
+		{inconvenience{}, 1, "working"},
+		{inconvenience{OffHours: time.Hour, Cost: 1}, 1, "1h outside working hours (cost 1.0)"},
+		{inconvenience{OffHours: 30 * time.Minute, Night: 30 * time.Minute, Cost: 2}, 1, "30m outside working hours, 30m at night (cost 2.0)"},
+		{inconvenience{Night: 90 * time.Minute, Cost: 9}, 2, "1h30m at night (cost 9.0, weight 2)"},
+	}
+
+	for _, tt := range tests {
+		if got := describeInconvenience(tt.cost, tt.weight); got != tt.want {
+			t.Errorf("describeInconvenience(%+v, %g) = %q, want %q", tt.cost, tt.weight, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,242 @@
+package main
+
+import (
+	"strings"
+	"time"
+)
+
+// FormatConfig is the "format" section of the config file. Dates and times
+// in every view are rendered through it.
+type FormatConfig struct {
+	// Clock is "12h" or "24h". Unset, the Clock view shows 24-hour time
+	// and the other views 12-hour time.
+	Clock string `yaml:"clock,omitempty"`
+	// Time is a Go layout for times of day, overriding Clock: "15.04"
+	Time string `yaml:"time,omitempty"`
+	// Date is "default", "iso", or a Go layout: "Mon 2 Jan 2006"
+	Date string `yaml:"date,omitempty"`
+	// ShortDate is the layout for dates shown without a year, in tables
+	// and notes. It defaults to a form matching Date.
+	ShortDate string `yaml:"short_date,omitempty"`
+	// Locale selects month and weekday names: en, de, fr, es, it, nl, pt
+	Locale string `yaml:"locale,omitempty"`
+}
+
+// timeFormatter renders dates and times the way the config asks for
+type timeFormatter struct {
+	timeLayout      string
+	secondsLayout   string
+	dateLayout      string
+	shortDateLayout string
+	// clockDateLayout is the date in the Clock view
+	clockDateLayout string
+	// names replaces English month and weekday names, nil for English
+	names *localeNames
+}
+
+// datePresets maps the named date formats to their full and short layouts
+var datePresets = map[string][2]string{
+	"default": {"Mon Jan 02, 2006", "Mon Jan 02"},
+	"iso":     {"Mon 2006-01-02", "Mon 2006-01-02"},
+}
+
+// defaultFormatter is used when the config has no format section
+var defaultFormatter = newTimeFormatter(FormatConfig{})
+
+// newTimeFormatter builds a formatter from the config. Unknown clock
+// styles, date presets and locales fall back to the defaults, which differ
+// for the Clock view: "15:04:05" and "Mon, Jan 02".
+func newTimeFormatter(config FormatConfig) timeFormatter {
+	f := timeFormatter{
+		timeLayout:    "3:04 PM",
+		secondsLayout: "3:04:05 PM",
+	}
+	if config.Clock == "" && config.Time == "" {
+		f.secondsLayout = "15:04:05"
+	}
+	if strings.EqualFold(config.Clock, "24h") {
+		f.timeLayout, f.secondsLayout = "15:04", "15:04:05"
+	}
+	if config.Time != "" {
+		f.timeLayout, f.secondsLayout = config.Time, config.Time
+	}
+
+	preset, ok := datePresets[strings.ToLower(config.Date)]
+	switch {
+	case config.Date == "":
+		preset = datePresets["default"]
+	case !ok:
+		// A custom layout, used for both full and short dates unless
+		// short_date says otherwise
+		preset = [2]string{config.Date, config.Date}
+	}
+	f.dateLayout, f.shortDateLayout = preset[0], preset[1]
+	if config.ShortDate != "" {
+		f.shortDateLayout = config.ShortDate
+	}
+	f.clockDateLayout = f.shortDateLayout
+	if config.Date == "" && config.ShortDate == "" {
+		f.clockDateLayout = "Mon, Jan 02"
+	}
+
+	if names, ok := locales[strings.ToLower(config.Locale)]; ok {
+		f.names = &names
+	}
+	return f
+}
+
+// Time renders the time of day: "3:04 PM"
+func (f timeFormatter) Time(t time.Time) string {
+	return f.format(t, f.timeLayout)
+}
+
+// Clock renders the time of day with seconds for the Clock view:
+// "15:04:05"
+func (f timeFormatter) Clock(t time.Time) string {
+	return f.format(t, f.secondsLayout)
+}
+
+// ClockDate renders the date in the Clock view: "Mon, Jan 02"
+func (f timeFormatter) ClockDate(t time.Time) string {
+	return f.format(t, f.clockDateLayout)
+}
+
+// Date renders the date: "Mon Jan 02, 2006"
+func (f timeFormatter) Date(t time.Time) string {
+	return f.format(t, f.dateLayout)
+}
+
+// ShortDate renders the date without the year: "Mon Jan 02"
+func (f timeFormatter) ShortDate(t time.Time) string {
+	return f.format(t, f.shortDateLayout)
+}
+
+// DateTime renders the time and date: "3:04 PM Mon Jan 02, 2006"
+func (f timeFormatter) DateTime(t time.Time) string {
+	return f.Time(t) + " " + f.Date(t)
+}
+
+// ShortDateTime renders the time and the date without the year
+func (f timeFormatter) ShortDateTime(t time.Time) string {
+	return f.Time(t) + " " + f.ShortDate(t)
+}
+
+// TimeInZone renders the time of day with its zone: "3:04 PM EST
+// (UTC-05:00)"
+func (f timeFormatter) TimeInZone(t time.Time) string {
+	if abbreviation := zoneAbbreviation(t); abbreviation != "" {
+		return f.Time(t) + " " + abbreviation + " (" + formatUTCOffset(t) + ")"
+	}
+	return f.Time(t) + " (" + formatUTCOffset(t) + ")"
+}
+
+// ShortDateTimeInZone renders the time and the date without the year,
+// with the zone: "3:04 PM Mon Jan 02 EST"
+func (f timeFormatter) ShortDateTimeInZone(t time.Time) string {
+	return f.ShortDateTime(t) + " " + zoneAbbreviationOrOffset(t)
+}
+
+// Shifts renders working hours as ranges of times of day: "9:00 AM -
+// 5:00 PM", or "8:00 AM - 12:00 PM, 1:00 PM - 5:00 PM"
+func (f timeFormatter) Shifts(hours workingHours) string {
+	midnight := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	shifts := make([]string, len(hours))
+	for i, s := range hours {
+		shifts[i] = f.Time(midnight.Add(s.Start)) + " - " + f.Time(midnight.Add(s.End))
+	}
+	return strings.Join(shifts, ", ")
+}
+
+// format renders t with a Go layout, putting in the locale's month and
+// weekday names. The layout is cut at each name element, since Go only
+// produces English names.
+func (f timeFormatter) format(t time.Time, layout string) string {
+	if f.names == nil {
+		return t.Format(layout)
+	}
+
+	var b strings.Builder
+	for layout != "" {
+		i, element := nextNameElement(layout)
+		if i < 0 {
+			b.WriteString(t.Format(layout))
+			break
+		}
+
+		b.WriteString(t.Format(layout[:i]))
+		switch element {
+		case "January":
+			b.WriteString(f.names.Months[t.Month()-1])
+		case "Jan":
+			b.WriteString(f.names.ShortMonths[t.Month()-1])
+		case "Monday":
+			b.WriteString(f.names.Weekdays[t.Weekday()])
+		case "Mon":
+			b.WriteString(f.names.ShortWeekdays[t.Weekday()])
+		}
+		layout = layout[i+len(element):]
+	}
+	return b.String()
+}
+
+// nextNameElement finds the first month or weekday name element in a Go
+// layout, preferring the long forms as time.Format does
+func nextNameElement(layout string) (int, string) {
+	for i := range layout {
+		for _, element := range []string{"January", "Jan", "Monday", "Mon"} {
+			if strings.HasPrefix(layout[i:], element) {
+				return i, element
+			}
+		}
+	}
+	return -1, ""
+}
+
+// localeNames holds a language's month and weekday names. Weekdays start
+// on Sunday to match time.Weekday.
+type localeNames struct {
+	Months        [12]string
+	ShortMonths   [12]string
+	Weekdays      [7]string
+	ShortWeekdays [7]string
+}
+
+// locales are the supported languages besides English
+var locales = map[string]localeNames{
+	"de": {
+		Months:        [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+		ShortMonths:   [12]string{"Jan", "Feb", "Mär", "Apr", "Mai", "Jun", "Jul", "Aug", "Sep", "Okt", "Nov", "Dez"},
+		Weekdays:      [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+		ShortWeekdays: [7]string{"So", "Mo", "Di", "Mi", "Do", "Fr", "Sa"},
+	},
+	"fr": {
+		Months:        [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+		ShortMonths:   [12]string{"janv", "févr", "mars", "avr", "mai", "juin", "juil", "août", "sept", "oct", "nov", "déc"},
+		Weekdays:      [7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+		ShortWeekdays: [7]string{"dim", "lun", "mar", "mer", "jeu", "ven", "sam"},
+	},
+	"es": {
+		Months:        [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+		ShortMonths:   [12]string{"ene", "feb", "mar", "abr", "may", "jun", "jul", "ago", "sep", "oct", "nov", "dic"},
+		Weekdays:      [7]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+		ShortWeekdays: [7]string{"dom", "lun", "mar", "mié", "jue", "vie", "sáb"},
+	},
+	"it": {
+		Months:        [12]string{"gennaio", "febbraio", "marzo", "aprile", "maggio", "giugno", "luglio", "agosto", "settembre", "ottobre", "novembre", "dicembre"},
+		ShortMonths:   [12]string{"gen", "feb", "mar", "apr", "mag", "giu", "lug", "ago", "set", "ott", "nov", "dic"},
+		Weekdays:      [7]string{"domenica", "lunedì", "martedì", "mercoledì", "giovedì", "venerdì", "sabato"},
+		ShortWeekdays: [7]string{"dom", "lun", "mar", "mer", "gio", "ven", "sab"},
+	},
+	"nl": {
+		Months:        [12]string{"januari", "februari", "maart", "april", "mei", "juni", "juli", "augustus", "september", "oktober", "november", "december"},
+		ShortMonths:   [12]string{"jan", "feb", "mrt", "apr", "mei", "jun", "jul", "aug", "sep", "okt", "nov", "dec"},
+		Weekdays:      [7]string{"zondag", "maandag", "dinsdag", "woensdag", "donderdag", "vrijdag", "zaterdag"},
+		ShortWeekdays: [7]string{"zo", "ma", "di", "wo", "do", "vr", "za"},
+	},
+	"pt": {
+		Months:        [12]string{"janeiro", "fevereiro", "março", "abril", "maio", "junho", "julho", "agosto", "setembro", "outubro", "novembro", "dezembro"},
+		ShortMonths:   [12]string{"jan", "fev", "mar", "abr", "mai", "jun", "jul", "ago", "set", "out", "nov", "dez"},
+		Weekdays:      [7]string{"domingo", "segunda-feira", "terça-feira", "quarta-feira", "quinta-feira", "sexta-feira", "sábado"},
+		ShortWeekdays: [7]string{"dom", "seg", "ter", "qua", "qui", "sex", "sáb"},
+	},
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimeFormatter(t *testing.T) {
+	moment := time.Date(2026, 3, 16, 15, 4, 5, 0, time.UTC) // Monday
+
+	tests := []struct {
+		name   string
+		config FormatConfig
+		render func(timeFormatter, time.Time) string
+		want   string
+	}{
+		{"default date time", FormatConfig{}, timeFormatter.DateTime, "3:04 PM Mon Mar 16, 2026"},
+		{"default clock", FormatConfig{}, timeFormatter.Clock, "15:04:05"},
+		{"default clock date", FormatConfig{}, timeFormatter.ClockDate, "Mon, Mar 16"},
+		{"12 hour clock", FormatConfig{Clock: "12h"}, timeFormatter.Clock, "3:04:05 PM"},
+		{"iso clock date", FormatConfig{Date: "iso"}, timeFormatter.ClockDate, "Mon 2026-03-16"},
+		{"24 hour", FormatConfig{Clock: "24h"}, timeFormatter.ShortDateTime, "15:04 Mon Mar 16"},
+		{"24 hour clock", FormatConfig{Clock: "24h"}, timeFormatter.Clock, "15:04:05"},
+		{"custom time", FormatConfig{Time: "15.04"}, timeFormatter.Time, "15.04"},
+		{"iso date", FormatConfig{Date: "iso"}, timeFormatter.Date, "Mon 2026-03-16"},
+		{"custom date", FormatConfig{Date: "Monday 2 January 2006"}, timeFormatter.Date, "Monday 16 March 2026"},
+		{"custom date is also short", FormatConfig{Date: "2 Jan"}, timeFormatter.ShortDate, "16 Mar"},
+		{"custom short date", FormatConfig{ShortDate: "02/01"}, timeFormatter.ShortDate, "16/03"},
+		{"german", FormatConfig{Locale: "de", Date: "Mon, 2. Jan 2006"}, timeFormatter.Date, "Mo, 16. Mär 2026"},
+		{"french long names", FormatConfig{Locale: "fr", Date: "Monday 2 January 2006"}, timeFormatter.Date, "lundi 16 mars 2026"},
+		{"spanish default layout", FormatConfig{Locale: "es", Clock: "24h"}, timeFormatter.DateTime, "15:04 lun mar 16, 2026"},
+		{"unknown locale", FormatConfig{Locale: "xx"}, timeFormatter.ShortDate, "Mon Mar 16"},
+		{"time in zone", FormatConfig{}, timeFormatter.TimeInZone, "3:04 PM UTC (UTC+00:00)"},
+		{"24 hour time in zone", FormatConfig{Clock: "24h"}, timeFormatter.TimeInZone, "15:04 UTC (UTC+00:00)"},
+		{"german date time in zone", FormatConfig{Locale: "de", Clock: "24h"}, timeFormatter.ShortDateTimeInZone, "15:04 Mo Mär 16 UTC"},
+		{"zone abbreviation untouched", FormatConfig{Locale: "de", Time: "15:04 MST"}, timeFormatter.Time, "15:04 UTC"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.render(newTimeFormatter(tt.config), moment); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatterShifts(t *testing.T) {
+	hours := workingHours{{Start: 8 * time.Hour, End: 12 * time.Hour}, {Start: 23 * time.Hour, End: 31 * time.Hour}}
+
+	if got, want := defaultFormatter.Shifts(hours), "8:00 AM - 12:00 PM, 11:00 PM - 7:00 AM"; got != want {
+		t.Errorf("Shifts() = %q, want %q", got, want)
+	}
+	if got, want := newTimeFormatter(FormatConfig{Clock: "24h"}).Shifts(hours), "08:00 - 12:00, 23:00 - 07:00"; got != want {
+		t.Errorf("24 hour Shifts() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,209 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxHistory caps the number of queries kept per view
+const maxHistory = 500
+
+// history is the list of past queries for one view, oldest first, backed by
+// a file so it survives restarts
+type history struct {
+	path    string
+	entries []string
+
+	// cursor is the entry recalled with up/down; len(entries) means the
+	// user is back on their own draft
+	cursor int
+	draft  string
+}
+
+// historySearch is an in-progress Ctrl-R search through a view's history
+type historySearch struct {
+	Active bool
+	Term   string
+	// Match is the index of the matching entry, or -1 when nothing matches
+	Match int
+	// Original is the input to restore when the search is cancelled
+	Original string
+}
+
+// getHistoryPath returns the history file for a view under the user's
+// config directory, such as ~/.config/aeon/convert_history
+func getHistoryPath(view string) string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "aeon", view+"_history")
+}
+
+// loadHistory reads the history file at path, one query per line. A
+// missing or unreadable file gives an empty history.
+func loadHistory(path string) history {
+	h := history{path: path}
+	if path != "" {
+		if data, err := os.ReadFile(path); err == nil {
+			for _, line := range strings.Split(string(data), "\n") {
+				if line = strings.TrimSpace(line); line != "" {
+					h.entries = append(h.entries, line)
+				}
+			}
+		}
+	}
+	if len(h.entries) > maxHistory {
+		h.entries = h.entries[len(h.entries)-maxHistory:]
+	}
+	h.cursor = len(h.entries)
+	return h
+}
+
+// add records a query as the most recent entry, moving it to the end if it
+// was already there, and saves the history file
+func (h *history) add(query string) error {
+	query = strings.TrimSpace(query)
+	h.cursor = len(h.entries)
+	if query == "" {
+		return nil
+	}
+
+	kept := h.entries[:0]
+	for _, entry := range h.entries {
+		if entry != query {
+			kept = append(kept, entry)
+		}
+	}
+	h.entries = append(kept, query)
+	if len(h.entries) > maxHistory {
+		h.entries = h.entries[len(h.entries)-maxHistory:]
+	}
+	h.cursor = len(h.entries)
+
+	if h.path == "" {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(h.path), 0755); err != nil {
+		return fmt.Errorf("Could not save history: %v", err)
+	}
+	if err := os.WriteFile(h.path, []byte(strings.Join(h.entries, "\n")+"\n"), 0600); err != nil {
+		return fmt.Errorf("Could not save history: %v", err)
+	}
+	return nil
+}
+
+// prev steps back to an older entry. current is the text in the input,
+// kept as the draft when browsing starts.
+func (h *history) prev(current string) (string, bool) {
+	if h.cursor == 0 {
+		return "", false
+	}
+	if h.cursor == len(h.entries) {
+		h.draft = current
+	}
+	h.cursor--
+	return h.entries[h.cursor], true
+}
+
+// next steps forward to a newer entry, ending on the draft
+func (h *history) next() (string, bool) {
+	if h.cursor >= len(h.entries) {
+		return "", false
+	}
+	h.cursor++
+	if h.cursor == len(h.entries) {
+		return h.draft, true
+	}
+	return h.entries[h.cursor], true
+}
+
+// search returns the newest entry before index from that contains term,
+// ignoring case, or -1 when there is none
+func (h *history) search(term string, from int) int {
+	term = strings.ToLower(term)
+	for i := min(from, len(h.entries)) - 1; i >= 0; i-- {
+		if strings.Contains(strings.ToLower(h.entries[i]), term) {
+			return i
+		}
+	}
+	return -1
+}
+
+// handleHistoryKey handles history keys for an active input: up/down recall
+// and Ctrl-R search. It reports whether the key was used.
+func (m *model) handleHistoryKey(msg tea.KeyMsg, input *textinput.Model, h *history) bool {
+	if m.search.Active {
+		return m.handleSearchKey(msg, input, h)
+	}
+
+	switch msg.String() {
+	case "up":
+		if entry, ok := h.prev(input.Value()); ok {
+			input.SetValue(entry)
+			input.CursorEnd()
+		}
+		return true
+	case "down":
+		if entry, ok := h.next(); ok {
+			input.SetValue(entry)
+			input.CursorEnd()
+		}
+		return true
+	case "ctrl+r":
+		m.search = historySearch{Active: true, Match: -1, Original: input.Value()}
+		return true
+	}
+	return false
+}
+
+// handleSearchKey handles keys during a Ctrl-R search. Typing narrows the
+// search, Ctrl-R finds an older match, Enter accepts the match into the
+// input and Esc restores what was typed before.
+func (m *model) handleSearchKey(msg tea.KeyMsg, input *textinput.Model, h *history) bool {
+	switch msg.Type {
+	case tea.KeyCtrlR:
+		if m.search.Match > 0 {
+			if older := h.search(m.search.Term, m.search.Match); older >= 0 {
+				m.search.Match = older
+			}
+		}
+	case tea.KeyEnter:
+		if m.search.Match >= 0 {
+			input.SetValue(h.entries[m.search.Match])
+			input.CursorEnd()
+			h.cursor = len(h.entries)
+		}
+		m.search = historySearch{}
+	case tea.KeyEsc, tea.KeyCtrlC, tea.KeyCtrlG:
+		input.SetValue(m.search.Original)
+		input.CursorEnd()
+		m.search = historySearch{}
+	case tea.KeyBackspace:
+		if m.search.Term != "" {
+			runes := []rune(m.search.Term)
+			m.search.Term = string(runes[:len(runes)-1])
+			m.search.Match = h.search(m.search.Term, len(h.entries))
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		m.search.Term += string(msg.Runes)
+		m.search.Match = h.search(m.search.Term, len(h.entries))
+	}
+	return true
+}
+
+// renderSearch shows the state of a Ctrl-R search in place of the input
+func (m model) renderSearch(h *history) string {
+	match := ""
+	if m.search.Match >= 0 {
+		match = h.entries[m.search.Match]
+	} else if m.search.Term != "" {
+		match = errorStyle.Render("no match")
+	}
+	return "(reverse-i-search)`" + m.search.Term + "': " + match
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestHistoryPersists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "aeon", "convert_history")
+
+	h := loadHistory(path)
+	for _, query := range []string{"3pm NYC to Berlin", "9am Tokyo to London", "3pm NYC to Berlin", "  "} {
+		if err := h.add(query); err != nil {
+			t.Fatalf("add(%q): %v", query, err)
+		}
+	}
+
+	// Repeated queries move to the end instead of appearing twice
+	want := []string{"9am Tokyo to London", "3pm NYC to Berlin"}
+	reloaded := loadHistory(path)
+	if len(reloaded.entries) != len(want) {
+		t.Fatalf("entries = %q, want %q", reloaded.entries, want)
+	}
+	for i := range want {
+		if reloaded.entries[i] != want[i] {
+			t.Errorf("entry %d = %q, want %q", i, reloaded.entries[i], want[i])
+		}
+	}
+}
+
+func TestHistoryRecall(t *testing.T) {
+	h := history{entries: []string{"first", "second", "third"}}
+	h.cursor = len(h.entries)
+
+	steps := []struct {
+		up     bool
+		want   string
+		wantOK bool
+	}{
+		{up: true, want: "third", wantOK: true},
+		{up: true, want: "second", wantOK: true},
+		{up: true, want: "first", wantOK: true},
+		{up: true, wantOK: false},
+		{up: false, want: "second", wantOK: true},
+		{up: false, want: "third", wantOK: true},
+		// Back on the draft that was being typed
+		{up: false, want: "draft", wantOK: true},
+		{up: false, wantOK: false},
+	}
+
+	for i, step := range steps {
+		var got string
+		var ok bool
+		if step.up {
+			got, ok = h.prev("draft")
+		} else {
+			got, ok = h.next()
+		}
+		if ok != step.wantOK || (ok && got != step.want) {
+			t.Errorf("step %d: got %q, %v, want %q, %v", i, got, ok, step.want, step.wantOK)
+		}
+	}
+}
+
+func TestHistorySearch(t *testing.T) {
+	h := history{entries: []string{"3pm NYC to Berlin", "9am Tokyo to London", "noon NYC to Tokyo"}}
+
+	tests := []struct {
+		term string
+		from int
+		want int
+	}{
+		{"nyc", 3, 2},
+		{"nyc", 2, 0},
+		{"TOKYO", 3, 2},
+		{"berlin", 3, 0},
+		{"sydney", 3, -1},
+		{"nyc", 0, -1},
+	}
+
+	for _, tt := range tests {
+		if got := h.search(tt.term, tt.from); got != tt.want {
+			t.Errorf("search(%q, %d) = %d, want %d", tt.term, tt.from, got, tt.want)
+		}
+	}
+}
+
+func TestRunConversionHistory(t *testing.T) {
+	dir := t.TempDir()
+	m := model{format: defaultFormatter, convertHistory: history{path: filepath.Join(dir, "convert_history")}}
+
+	m.runConversion("3pm Berlin to Tokyo")
+	if m.err != nil || m.lastQuery.SourceLoc == nil {
+		t.Fatalf("runConversion error %v, last query %+v", m.err, m.lastQuery)
+	}
+	m.runConversion("3pm Nowhereville to Tokyo")
+	if m.lastQuery.SourceLoc != nil {
+		t.Errorf("failed query kept as the last query: %+v", m.lastQuery)
+	}
+	if got := loadHistory(m.convertHistory.path).entries; len(got) != 1 || got[0] != "3pm Berlin to Tokyo" {
+		t.Errorf("history = %q, want only the successful query", got)
+	}
+
+	// A history file that can't be written is reported
+	blocker := filepath.Join(dir, "file")
+	if err := os.WriteFile(blocker, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	m.convertHistory.path = filepath.Join(blocker, "convert_history")
+	m.runConversion("9am Tokyo to Berlin")
+	if m.err == nil {
+		t.Errorf("runConversion hid the history write failure")
+	}
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"aeon/timezones"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WorkingHoursConfig is the "working_hours" section of the config file.
+// Hours are written as ranges of hours, with split shifts separated by
+// spaces or commas: "9-17", "8:30-16:30", "9am-1pm 2pm-6pm", "22-6".
+type WorkingHoursConfig struct {
+	// Default applies to zones and people without their own hours
+	Default string `yaml:"default,omitempty"`
+	// Zones maps a zone, as typed in queries, to its hours:
+	// "Bangalore: 11-20"
+	Zones map[string]string `yaml:"zones,omitempty"`
+	// People lets queries name participants instead of zones
+	People map[string]PersonConfig `yaml:"people,omitempty"`
+}
+
+// PersonConfig is a participant known by name
+type PersonConfig struct {
+	Zone  string `yaml:"zone"`
+	Hours string `yaml:"hours,omitempty"`
+}
+
+// shift is a stretch of working time, as offsets from local midnight. An
+// overnight shift ends past 24h.
+type shift struct {
+	Start time.Duration
+	End   time.Duration
+}
+
+// workingHours is the shifts someone works each day, in order
+type workingHours []shift
+
+// defaultWorkingHours is 9 AM to 5 PM
+var defaultWorkingHours = workingHours{{Start: 9 * time.Hour, End: 17 * time.Hour}}
+
+// parseWorkingHours reads hours such as "9-17" or "8-12 13-17"
+func parseWorkingHours(text string) (workingHours, error) {
+	fields := strings.FieldsFunc(text, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	})
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("No working hours given")
+	}
+
+	var hours workingHours
+	for _, field := range fields {
+		s, ok := parseShift(field)
+		if !ok {
+			return nil, fmt.Errorf("Invalid working hours '%s'. Use a range such as 9-17 or 9am-5pm", field)
+		}
+		hours = append(hours, s)
+	}
+
+	sort.Slice(hours, func(i, j int) bool {
+		return hours[i].Start < hours[j].Start
+	})
+	for i := 1; i < len(hours); i++ {
+		if hours[i].Start < hours[i-1].End {
+			return nil, fmt.Errorf("Working hours '%s' overlap", text)
+		}
+	}
+	return hours, nil
+}
+
+// parseShift reads one range of hours: "9-17", "8:30-16:30", "9am-5pm".
+// A range ending at or before its start runs overnight: "22-6".
+func parseShift(text string) (shift, bool) {
+	from, to, ok := strings.Cut(strings.ToLower(text), "-")
+	if !ok {
+		return shift{}, false
+	}
+
+	start, ok := parseHourOfDay(from)
+	if !ok || start >= 24*time.Hour {
+		return shift{}, false
+	}
+	end, ok := parseHourOfDay(to)
+	if !ok {
+		return shift{}, false
+	}
+	if end <= start {
+		end += 24 * time.Hour
+	}
+	return shift{Start: start, End: end}, true
+}
+
+// parseHourOfDay reads a time of day as an offset from midnight: "9",
+// "16:30", "5pm". "24" is accepted as the end of the day.
+func parseHourOfDay(text string) (time.Duration, bool) {
+	meridiem := ""
+	for _, suffix := range []string{"am", "pm"} {
+		if strings.HasSuffix(text, suffix) {
+			meridiem, text = suffix, strings.TrimSuffix(text, suffix)
+		}
+	}
+
+	hourText, minuteText, hasMinutes := strings.Cut(text, ":")
+	hour, err := strconv.Atoi(hourText)
+	if err != nil || hour < 0 || hour > 24 {
+		return 0, false
+	}
+	minute := 0
+	if hasMinutes {
+		minute, err = strconv.Atoi(minuteText)
+		if err != nil || len(minuteText) != 2 || minute > 59 {
+			return 0, false
+		}
+	}
+
+	switch meridiem {
+	case "am", "pm":
+		if hour < 1 || hour > 12 {
+			return 0, false
+		}
+		hour %= 12
+		if meridiem == "pm" {
+			hour += 12
+		}
+	}
+	if hour == 24 && minute > 0 {
+		return 0, false
+	}
+	return time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute, true
+}
+
+// defaultHours returns the configured default hours, or 9 AM to 5 PM
+func (c WorkingHoursConfig) defaultHours() (workingHours, error) {
+	if c.Default == "" {
+		return defaultWorkingHours, nil
+	}
+	return parseWorkingHours(c.Default)
+}
+
+// zoneHours returns the configured hours for a zone typed as name. A
+// configured zone matches when it is the same name, ignoring case, or an
+// alias for it, so "Bangalore" in the config also covers "BLR". Other
+// names for the same time zone, such as "Kolkata", get the default hours.
+func (c WorkingHoursConfig) zoneHours(name string) (workingHours, error) {
+	names := make([]string, 0, len(c.Zones))
+	for configured := range c.Zones {
+		names = append(names, configured)
+	}
+	sort.Strings(names)
+
+	canonical := timezones.Canonical(name)
+	for _, configured := range names {
+		if timezones.Canonical(configured) == canonical {
+			return parseWorkingHours(c.Zones[configured])
+		}
+	}
+	return c.defaultHours()
+}
+
+// person returns the configured participant with the given name, ignoring
+// case
+func (c WorkingHoursConfig) person(name string) (string, PersonConfig, bool) {
+	for configured, person := range c.People {
+		if strings.EqualFold(configured, name) {
+			return configured, person, true
+		}
+	}
+	return "", PersonConfig{}, false
+}
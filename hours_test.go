@@ -0,0 +1,118 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseWorkingHours(t *testing.T) {
+	tests := []struct {
+		input     string
+		want      workingHours
+		wantError bool
+	}{
+		{"9-17", workingHours{{9 * time.Hour, 17 * time.Hour}}, false},
+		{"8:30-16:30", workingHours{{8*time.Hour + 30*time.Minute, 16*time.Hour + 30*time.Minute}}, false},
+		{"9am-5pm", workingHours{{9 * time.Hour, 17 * time.Hour}}, false},
+		{"12am-12pm", workingHours{{0, 12 * time.Hour}}, false},
+		{"0-24", workingHours{{0, 24 * time.Hour}}, false},
+		{"22-6", workingHours{{22 * time.Hour, 30 * time.Hour}}, false},
+		{"13-17 8-12", workingHours{{8 * time.Hour, 12 * time.Hour}, {13 * time.Hour, 17 * time.Hour}}, false},
+		{"8-12, 13-17", workingHours{{8 * time.Hour, 12 * time.Hour}, {13 * time.Hour, 17 * time.Hour}}, false},
+		{"", nil, true},
+		{"9", nil, true},
+		{"9-25", nil, true},
+		{"13pm-5pm", nil, true},
+		{"9:5-17", nil, true},
+		{"24-6", nil, true},
+		{"8-13 12-17", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := parseWorkingHours(tt.input)
+			if tt.wantError {
+				if err == nil {
+					t.Errorf("parseWorkingHours(%q) = %v, want error", tt.input, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseWorkingHours(%q) error: %v", tt.input, err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("parseWorkingHours(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("parseWorkingHours(%q)[%d] = %v, want %v", tt.input, i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseParticipant(t *testing.T) {
+	config := WorkingHoursConfig{
+		Default: "8-16",
+		// Both in Asia/Kolkata
+		Zones: map[string]string{"Bangalore": "11-20", "Mumbai": "9-18"},
+		People: map[string]PersonConfig{
+			"Priya": {Zone: "Bangalore"},
+			"Ravi":  {Zone: "mumbai"},
+			"Sam":   {Zone: "NYC", Hours: "7-11 14-18"},
+		},
+	}
+
+	tests := []struct {
+		input     string
+		wantName  string
+		wantZone  string
+		wantHours string
+		wantError bool
+	}{
+		{input: "Berlin", wantName: "Berlin", wantZone: "Europe/Berlin", wantHours: "8-16"},
+		{input: "Berlin 10-18", wantName: "Berlin", wantZone: "Europe/Berlin", wantHours: "10-18"},
+		{input: " Los Angeles 8-12 13-17 ", wantName: "Los Angeles", wantZone: "America/Los_Angeles", wantHours: "8-12 13-17"},
+		{input: "Kolkata", wantName: "Kolkata", wantZone: "Asia/Kolkata", wantHours: "8-16"},
+		{input: "BLR", wantName: "BLR", wantZone: "Asia/Kolkata", wantHours: "11-20"},
+		{input: "bengaluru", wantName: "bengaluru", wantZone: "Asia/Kolkata", wantHours: "11-20"},
+		{input: "Mumbai", wantName: "Mumbai", wantZone: "Asia/Kolkata", wantHours: "9-18"},
+		{input: "MUMBAI", wantName: "MUMBAI", wantZone: "Asia/Kolkata", wantHours: "9-18"},
+		{input: "priya", wantName: "Priya", wantZone: "Asia/Kolkata", wantHours: "11-20"},
+		{input: "Ravi", wantName: "Ravi", wantZone: "Asia/Kolkata", wantHours: "9-18"},
+		{input: "Sam", wantName: "Sam", wantZone: "America/New_York", wantHours: "7-11 14-18"},
+		{input: "Sam 9-17", wantName: "Sam", wantZone: "America/New_York", wantHours: "9-17"},
+		{input: "9-17", wantError: true},
+		{input: "Berlin 8-13 12-17", wantError: true},
+		{input: "Nowhereville", wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := parseParticipant(tt.input, config)
+			if tt.wantError {
+				if err == nil {
+					t.Errorf("parseParticipant(%q) = %+v, want error", tt.input, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseParticipant(%q) error: %v", tt.input, err)
+			}
+
+			want, _ := parseWorkingHours(tt.wantHours)
+			if got.Name != tt.wantName || got.Loc.String() != tt.wantZone {
+				t.Errorf("parseParticipant(%q) = %s in %s, want %s in %s", tt.input, got.Name, got.Loc, tt.wantName, tt.wantZone)
+			}
+			if len(got.Hours) != len(want) {
+				t.Fatalf("parseParticipant(%q) hours = %v, want %v", tt.input, got.Hours, want)
+			}
+			for i := range want {
+				if got.Hours[i] != want[i] {
+					t.Errorf("parseParticipant(%q) hours = %v, want %v", tt.input, got.Hours, want)
+				}
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"aeon/timezones"
+	"flag"
 	"fmt"
 	"os"
 	"strings"
@@ -40,14 +41,57 @@ type model struct {
 	addZoneInput  textinput.Model
 
 	// Convert view state
-	convertInput  textinput.Model
-	convertResult string
-	convertActive bool
+	convertInput   textinput.Model
+	convertResult  string
+	convertActive  bool
+	convertPreview string
+	// convertAnnouncement is the last result as one line to paste into a
+	// message, empty when the result has no such form
+	convertAnnouncement string
+	// lastQuery is the last conversion that parsed, for swapping its
+	// zones or picking a new target. SourceLoc is nil when there is none.
+	lastQuery conversionQuery
+
+	// Target picker for re-running the last conversion
+	targetPickerActive bool
+	targetPickerIndex  int
 
 	// Meeting view state
 	meetingInput  textinput.Model
 	meetingResult string
 	meetingActive bool
+	// meetingAnnouncement is the first overlap as one line, copied with Y
+	meetingAnnouncement string
+	// lastMeeting is the last meeting query that parsed. Its participants
+	// are drawn on the timeline, which covers meetingTimelineHours from the
+	// start of its day.
+	lastMeeting          meetingQuery
+	meetingTimelineHours int
+	// meetingPlan is the rotation shown for lastMeeting, which e exports
+	meetingPlan []suggestion
+
+	// Query history for the Convert and Meeting views
+	convertHistory history
+	meetingHistory history
+	search         historySearch
+
+	// Preferences from the config file
+	parseOpts parseOptions
+
+	// format renders dates and times in every view
+	format timeFormatter
+
+	// workingHours is the meeting finder's hours by zone and person
+	workingHours WorkingHoursConfig
+	// meetingConfig ranks meeting slots when working hours don't overlap
+	meetingConfig MeetingConfig
+
+	// dstNoticeDays is how close to a DST change a converted time must be
+	// for the change to be pointed out
+	dstNoticeDays int
+
+	// notice is a short status message, such as confirming a copy
+	notice string
 
 	err error
 }
@@ -99,8 +143,10 @@ var (
 )
 
 func initialModel() model {
-	// Load config or use defaults
-	zones := loadZonesFromConfig()
+	// Load config or use defaults. A config that can't be read is reported
+	// rather than replaced.
+	config, configErr := loadConfig()
+	zones := loadZonesFromConfig(config)
 	if len(zones) == 0 {
 		// Default zones if no config
 		local := Zone{
@@ -140,6 +186,19 @@ func initialModel() model {
 		addZoneInput: azi,
 		convertInput: ti,
 		meetingInput: mi,
+		parseOpts:    parseOptions{Rollover: config.Rollover},
+
+		format:        newTimeFormatter(config.Format),
+		dstNoticeDays: config.dstNoticeDays(),
+
+		workingHours:         config.WorkingHours,
+		meetingConfig:        config.Meeting,
+		meetingTimelineHours: 24,
+
+		convertHistory: loadHistory(getHistoryPath("convert")),
+		meetingHistory: loadHistory(getHistoryPath("meeting")),
+
+		err: configErr,
 	}
 }
 
@@ -158,6 +217,8 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		m.notice = ""
+
 		// Handle add zone input mode
 		if m.addZoneActive {
 			switch msg.String() {
@@ -172,8 +233,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					if err := m.addZone(zoneName); err != nil {
 						m.err = err
 					} else {
-						m.err = nil
-						saveZonesToConfig(m.zones)
+						m.err = saveZonesToConfig(m.zones)
 					}
 				}
 				m.addZoneActive = false
@@ -188,35 +248,68 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		// Handle convert input mode
 		if m.convertActive {
+			if m.handleHistoryKey(msg, &m.convertInput, &m.convertHistory) {
+				m.convertPreview = m.previewConversion(m.convertInput.Value())
+				return m, nil
+			}
+
 			switch msg.String() {
 			case "esc":
 				m.convertActive = false
 				m.convertInput.Blur()
 				return m, nil
 			case "enter":
-				m.convertResult = m.processConversion(m.convertInput.Value())
+				// Keep the query so it can be edited and run again
+				m.runConversion(m.convertInput.Value())
 				m.convertActive = false
 				m.convertInput.Blur()
-				m.convertInput.SetValue("")
 				return m, nil
 			default:
 				m.convertInput, cmd = m.convertInput.Update(msg)
+				m.convertPreview = m.previewConversion(m.convertInput.Value())
 				return m, cmd
 			}
 		}
 
+		// Handle picking a new target for the last conversion
+		if m.targetPickerActive {
+			switch msg.String() {
+			case "esc":
+				m.targetPickerActive = false
+			case "up", "k":
+				if m.targetPickerIndex > 0 {
+					m.targetPickerIndex--
+				}
+			case "down", "j":
+				if m.targetPickerIndex < len(m.zones)-1 {
+					m.targetPickerIndex++
+				}
+			case "enter":
+				m.targetPickerActive = false
+				query := m.lastQuery
+				query.Targets = []conversionTarget{m.clockTargets()[m.targetPickerIndex]}
+				query.AllTargets = false
+				m.rerunConversion(query)
+			}
+			return m, nil
+		}
+
 		// Handle meeting input mode
 		if m.meetingActive {
+			if m.handleHistoryKey(msg, &m.meetingInput, &m.meetingHistory) {
+				return m, nil
+			}
+
 			switch msg.String() {
 			case "esc":
 				m.meetingActive = false
 				m.meetingInput.Blur()
 				return m, nil
 			case "enter":
-				m.meetingResult = m.processMeeting(m.meetingInput.Value())
+				// Keep the zones so they can be edited and run again
+				m.runMeeting(m.meetingInput.Value())
 				m.meetingActive = false
 				m.meetingInput.Blur()
-				m.meetingInput.SetValue("")
 				return m, nil
 			default:
 				m.meetingInput, cmd = m.meetingInput.Update(msg)
@@ -257,8 +350,73 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "d":
 			if m.currentView == clockView && len(m.zones) > 1 {
 				m.deleteSelectedZone()
-				saveZonesToConfig(m.zones)
+				m.err = saveZonesToConfig(m.zones)
+			}
+
+		case "y", "Y":
+			if m.currentView == clockView {
+				break
+			}
+
+			text := m.convertResult
+			if m.currentView == meetingView {
+				text = m.meetingResult
+			}
+			if msg.String() == "Y" && m.currentView == convertView && m.convertAnnouncement != "" {
+				text = m.convertAnnouncement
 			}
+			if msg.String() == "Y" && m.currentView == meetingView && m.meetingAnnouncement != "" {
+				text = m.meetingAnnouncement
+			}
+
+			notice, err := copyToClipboard(text)
+			m.notice, m.err = notice, err
+
+		case "e":
+			if m.currentView != meetingView {
+				break
+			}
+			notice, err := m.exportRotation()
+			m.notice, m.err = notice, err
+
+		case "g":
+			if m.currentView != meetingView {
+				break
+			}
+			if m.meetingTimelineHours == 24 {
+				m.meetingTimelineHours = 48
+			} else {
+				m.meetingTimelineHours = 24
+			}
+
+		case "s":
+			if m.currentView != convertView {
+				break
+			}
+			if m.lastQuery.SourceLoc == nil {
+				m.notice = "Run a conversion first"
+				break
+			}
+			if query, ok := m.lastQuery.swapped(); ok {
+				m.rerunConversion(query)
+			} else {
+				m.notice = "Swap works on conversions with a single target"
+			}
+
+		case "t":
+			if m.currentView != convertView {
+				break
+			}
+			if m.lastQuery.SourceLoc == nil {
+				m.notice = "Run a conversion first"
+				break
+			}
+			if len(m.zones) == 0 {
+				m.notice = "Add zones in the Clock view to pick a target"
+				break
+			}
+			m.targetPickerActive = true
+			m.targetPickerIndex = 0
 
 		case "up", "k":
 			if m.currentView == clockView && m.selectedZone > 0 {
@@ -274,9 +432,12 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if m.currentView == convertView {
 				m.convertActive = true
 				m.convertInput.Focus()
+				m.convertInput.CursorEnd()
+				m.convertPreview = m.previewConversion(m.convertInput.Value())
 			} else if m.currentView == meetingView {
 				m.meetingActive = true
 				m.meetingInput.Focus()
+				m.meetingInput.CursorEnd()
 			}
 		}
 
@@ -326,6 +487,11 @@ func (m model) View() string {
 		b.WriteString(errorStyle.Render(fmt.Sprintf("Error: %v", m.err)))
 	}
 
+	if m.notice != "" {
+		b.WriteString("\n")
+		b.WriteString(helpStyle.Render(m.notice))
+	}
+
 	// Help text
 	b.WriteString("\n")
 	b.WriteString(helpStyle.Render(m.getHelpText()))
@@ -349,8 +515,8 @@ func (m model) renderClockView() string {
 		t := now.In(zone.Location)
 
 		// Format: Zone Name    HH:MM:SS    Day, Mon DD
-		timeStr := t.Format("15:04:05")
-		dateStr := t.Format("Mon, Jan 02")
+		timeStr := m.format.Clock(t)
+		dateStr := m.format.ClockDate(t)
 		offset := t.Format("-07:00")
 
 		line := fmt.Sprintf("%-15s  %s  %s  (UTC%s)",
@@ -372,11 +538,30 @@ func (m model) renderConvertView() string {
 
 	if m.convertActive {
 		b.WriteString("Enter conversion query:\n\n")
-		b.WriteString(m.convertInput.View())
+		if m.search.Active {
+			b.WriteString(m.renderSearch(&m.convertHistory))
+		} else {
+			b.WriteString(m.convertInput.View())
+		}
 		b.WriteString("\n\n")
+		if m.convertPreview != "" {
+			b.WriteString(m.convertPreview)
+			return b.String()
+		}
 		b.WriteString(helpStyle.Render("Examples: tomorrow 3pm NYC to Berlin • in 2 hours Tokyo to NYC • next monday noon LA to London"))
 		b.WriteString("\n")
 		b.WriteString(helpStyle.Render("          2026-01-20 3pm NYC to Berlin • midnight NYC to Berlin"))
+	} else if m.targetPickerActive {
+		b.WriteString("Convert to:\n\n")
+		for i, zone := range m.zones {
+			line := fmt.Sprintf("%-15s  %s", zone.Name, zone.Location)
+			if i == m.targetPickerIndex {
+				b.WriteString(clockSelectedStyle.Render(line))
+			} else {
+				b.WriteString(clockStyle.Render(line))
+			}
+			b.WriteString("\n")
+		}
 	} else {
 		b.WriteString("Press Enter to start a conversion\n\n")
 		b.WriteString(helpStyle.Render("Supports: relative times (tomorrow, in 2 hours, next monday)"))
@@ -396,14 +581,28 @@ func (m model) renderMeetingView() string {
 
 	if m.meetingActive {
 		b.WriteString("Enter time zones (comma-separated):\n\n")
-		b.WriteString(m.meetingInput.View())
+		if m.search.Active {
+			b.WriteString(m.renderSearch(&m.meetingHistory))
+		} else {
+			b.WriteString(m.meetingInput.View())
+		}
 		b.WriteString("\n\n")
-		b.WriteString(helpStyle.Render("Press Enter to find meeting slots, Esc to cancel"))
+		b.WriteString(helpStyle.Render("Press Enter to find meeting slots, Esc to cancel  •  ↑/↓: History  •  Ctrl-R: Search"))
 	} else {
 		b.WriteString("Press Enter to find meeting slots\n\n")
 		if m.meetingResult != "" {
 			b.WriteString(resultStyle.Render(m.meetingResult))
 		}
+		// The timeline covers a single day, not rotations or whole weeks
+		if len(m.lastMeeting.Participants) > 0 && m.lastMeeting.Rotation == nil && len(m.lastMeeting.Days) == 1 {
+			width := m.width
+			if width == 0 {
+				width = 80
+			}
+			from, _ := dayBounds(m.lastMeeting.Days[0], time.Local)
+			b.WriteString("\n\n")
+			b.WriteString(renderTimeline(m.lastMeeting.Participants, from, m.meetingTimelineHours, width, m.meetingConfig.sleepHours(), m.format))
+		}
 	}
 
 	return b.String()
@@ -417,9 +616,15 @@ func (m model) getHelpText() string {
 		}
 		return "a: Add zone  •  d: Delete  •  ↑/↓: Select  •  ←/→: Switch views  •  q: Quit"
 	case convertView:
-		return "Enter: Convert  •  ←/→: Switch views  •  q: Quit"
+		if m.convertActive {
+			return "Enter: Convert  •  ↑/↓: History  •  Ctrl-R: Search  •  Esc: Cancel"
+		}
+		if m.targetPickerActive {
+			return "↑/↓: Select  •  Enter: Convert  •  Esc: Cancel"
+		}
+		return "Enter: Convert  •  s: Swap  •  t: Target  •  y: Copy  •  Y: Copy announcement  •  ←/→: Switch views  •  q: Quit"
 	case meetingView:
-		return "Enter: Find slots  •  ←/→: Switch views  •  q: Quit"
+		return "Enter: Find slots  •  g: 24h/48h timeline  •  e: Export rotation  •  y: Copy  •  Y: Copy announcement  •  ←/→: Switch views  •  q: Quit"
 	}
 	return ""
 }
@@ -450,127 +655,322 @@ func (m *model) deleteSelectedZone() {
 	}
 }
 
-func (m model) processConversion(input string) string {
-	input = strings.TrimSpace(input)
-	if input == "" {
-		return errorStyle.Render("Error: Empty input")
-	}
+// runConversion answers the query typed in the Convert view. A successful
+// conversion is kept for swapping and re-targeting, and the query is saved
+// to history.
+func (m *model) runConversion(input string) {
+	m.lastQuery, m.convertAnnouncement = conversionQuery{}, ""
 
-	// Parse format: "[time expression] [source zone] to [target zone]"
-	parts := strings.Split(input, " to ")
-	if len(parts) != 2 {
-		return errorStyle.Render("Error: Use format 'tomorrow 3pm NYC to Berlin' or '3pm NYC to Berlin'")
+	result, query, err := m.answerConversion(input)
+	if err != nil {
+		m.convertResult = errorStyle.Render(fmt.Sprintf("Error: %v", err))
+		return
 	}
 
-	sourcePart := strings.TrimSpace(parts[0])
-	targetZone := strings.TrimSpace(parts[1])
-
-	// Split source part into time expression and zone
-	// We need to be smart about this since time expressions can be multi-word
-	sourceWords := strings.Fields(sourcePart)
-	if len(sourceWords) < 2 {
-		return errorStyle.Render("Error: Specify time and source zone")
+	m.convertResult = result
+	if query.SourceLoc != nil {
+		m.lastQuery = query
+		m.convertAnnouncement = m.announceQuery(query)
 	}
+	m.err = m.convertHistory.add(input)
+}
 
-	// Strategy: Last word(s) are likely the zone, everything before is time
-	// Try progressively taking more words as zone until resolution succeeds
-	var sourceZone string
-	var timeExpr string
-	var sourceLoc *time.Location
-	var err error
-
-	for i := len(sourceWords) - 1; i > 0; i-- {
-		candidateZone := strings.Join(sourceWords[i:], " ")
-		candidateLoc, resolveErr := timezones.Resolve(candidateZone)
+// convert answers a Convert view query. It is shared by the TUI and the
+// one-shot command line mode.
+func (m model) convert(input string) (string, error) {
+	result, _, err := m.answerConversion(input)
+	return result, err
+}
 
-		if resolveErr == nil {
-			sourceZone = candidateZone
-			sourceLoc = candidateLoc
-			timeExpr = strings.Join(sourceWords[:i], " ")
-			break
-		}
+// answerConversion answers a Convert view query, also returning the parsed
+// conversion. Its SourceLoc is nil for duration queries.
+func (m model) answerConversion(input string) (string, conversionQuery, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return "", conversionQuery{}, fmt.Errorf("Empty input")
 	}
 
-	if sourceLoc == nil {
-		// Couldn't resolve zone, try last word only
-		sourceZone = sourceWords[len(sourceWords)-1]
-		sourceLoc, err = timezones.Resolve(sourceZone)
+	// "how long until 9am Tokyo", "time between 3pm NYC and 9am Sydney"
+	if durationQuery, ok, err := parseDurationQuery(input); ok {
 		if err != nil {
-			return errorStyle.Render(fmt.Sprintf("Error: %v", err))
+			return "", conversionQuery{}, err
 		}
-		timeExpr = strings.Join(sourceWords[:len(sourceWords)-1], " ")
+		result, err := m.processDuration(durationQuery)
+		return result, conversionQuery{}, err
 	}
 
-	// Resolve target location
-	targetLoc, err := timezones.Resolve(targetZone)
+	query, err := parseConversionQuery(input)
 	if err != nil {
-		return errorStyle.Render(fmt.Sprintf("Error: %v", err))
+		return "", conversionQuery{}, err
+	}
+	result, err := m.convertQuery(query)
+	return result, query, err
+}
+
+// convertQuery answers a parsed conversion query
+func (m model) convertQuery(query conversionQuery) (string, error) {
+	timeExpr := query.TimeExpr
+	sourceZone, sourceLoc := query.SourceZone, query.SourceLoc
+
+	targets := query.Targets
+	if query.AllTargets {
+		targets = m.clockTargets()
 	}
 
-	// Parse the time expression in the source timezone context
-	now := time.Now().In(sourceLoc)
-	parsedTime, err := parseTimeWithContext(timeExpr, now)
+	resolved, err := resolveTimeExpr(timeExpr, sourceLoc, query.Offsets, time.Now(), m.parseOpts)
 	if err != nil {
-		return errorStyle.Render(fmt.Sprintf("Error: Invalid time expression '%s': %v", timeExpr, err))
+		return "", err
+	}
+	parsedTime, wall := resolved.Parsed, resolved.Wall
+	base, source := resolved.Base, resolved.Time
+
+	// A bare date covers the whole day, which spans two dates elsewhere
+	allDay := parsedTime.AllDay() && len(query.Offsets) == 0
+	var dayStart, dayEnd time.Time
+	if allDay {
+		dayStart, dayEnd = dayBounds(parsedTime.Time, sourceLoc)
+		wall = wallClock{}
+	}
+
+	// Mark hedged times such as "noonish" or "around 3"
+	approx := ""
+	if parsedTime.Approximate {
+		approx = "~"
 	}
 
-	// Ensure the parsed time is in the source location
-	source := time.Date(
-		parsedTime.Time.Year(), parsedTime.Time.Month(), parsedTime.Time.Day(),
-		parsedTime.Time.Hour(), parsedTime.Time.Minute(), parsedTime.Time.Second(),
-		0, sourceLoc,
-	)
+	sourceDisplay := m.format.DateTime(source)
+	sourceWeek := isoWeekDate(source)
+	if allDay {
+		sourceDisplay = "all day " + m.format.Date(dayStart)
+		sourceWeek = isoWeekDate(dayStart)
+	}
 
-	// Convert to target timezone
-	target := source.In(targetLoc)
+	// Whole days are compared from their first instant
+	from := source
+	if allDay {
+		from = dayStart
+	}
 
-	// Format output with more context
-	sourceDisplay := source.Format("3:04 PM Mon Jan 02, 2006")
-	targetDisplay := target.Format("3:04 PM Mon Jan 02, 2006")
+	var result string
+	if len(targets) == 1 {
+		targetZone, targetLoc := targets[0].Zone, targets[0].Loc
+
+		// Convert to target timezone
+		target := from.In(targetLoc)
+		targetDisplay := m.format.DateTime(target)
+		if allDay {
+			targetDisplay = fmt.Sprintf("%s – %s",
+				m.format.ShortDateTime(target),
+				m.format.DateTime(dayEnd.In(targetLoc)),
+			)
+		} else if days := calendarDays(from, target); days != 0 {
+			targetDisplay += fmt.Sprintf(" (%s day)", formatDayDelta(days))
+		}
 
-	return fmt.Sprintf("%s in %s\n  →  %s in %s",
-		sourceDisplay,
-		sourceZone,
-		targetDisplay,
-		targetZone,
-	)
-}
+		result = fmt.Sprintf("%s%s in %s\n  →  %s%s in %s",
+			approx, sourceDisplay,
+			sourceZone,
+			approx, targetDisplay,
+			targetZone,
+		)
+
+		// Abbreviations, offsets and the difference between the zones
+		rows := [][]string{
+			append([]string{sourceZone}, zoneDetails(from, from)...),
+			append([]string{targetZone}, zoneDetails(target, from)...),
+		}
+		rows[0][len(rows[0])-1] = ""
+		result += "\n\n" + formatTable(rows, "  ")
+		result += fmt.Sprintf("\n\nISO week  %s  →  %s", sourceWeek, isoWeekDate(target))
+	} else {
+		// One aligned row per target, marking days that differ from the source
+		rows := make([][]string, len(targets))
+		for i, t := range targets {
+			local := from.In(t.Loc)
+			delta := formatDayDelta(calendarDays(from, local))
+			if allDay {
+				span := fmt.Sprintf("%s – %s", m.format.ShortDateTime(local), m.format.ShortDateTime(dayEnd.In(t.Loc)))
+				rows[i] = append(append([]string{t.Zone, span}, zoneDetails(local, from)...), delta)
+				continue
+			}
+			rows[i] = append(append([]string{t.Zone, approx + m.format.Time(local), m.format.ShortDate(local)}, zoneDetails(local, from)...), delta)
+		}
 
-func (m model) processMeeting(input string) string {
-	zones := strings.Split(input, ",")
-	if len(zones) < 2 {
-		return errorStyle.Render("Error: Enter at least 2 zones")
+		sourceDetails := formatUTCOffset(from)
+		if abbreviation := zoneAbbreviation(from); abbreviation != "" {
+			sourceDetails = abbreviation + ", " + sourceDetails
+		}
+		result = fmt.Sprintf("%s%s in %s (%s)\n\n%s",
+			approx, sourceDisplay, sourceZone, sourceDetails, formatTable(rows, "  "))
+		result += fmt.Sprintf("\n\nISO week  %s in %s", sourceWeek, sourceZone)
 	}
 
-	// For MVP, show business hours for each zone
-	var b strings.Builder
-	b.WriteString("Business Hours (9 AM - 5 PM local):\n\n")
+	// Say which day a bare time was moved to
+	switch parsedTime.RolledDays {
+	case 1:
+		result += fmt.Sprintf("\n\n↻ Using the next %s in %s: tomorrow, %s",
+			m.format.Time(base), sourceZone, m.format.ShortDate(base))
+	case -1:
+		result += fmt.Sprintf("\n\n↻ Using the last %s in %s: yesterday, %s",
+			m.format.Time(base), sourceZone, m.format.ShortDate(base))
+	}
 
-	now := time.Now()
-	for _, zoneName := range zones {
-		zoneName = strings.TrimSpace(zoneName)
-		loc, err := timezones.Resolve(zoneName)
-		if err != nil {
-			b.WriteString(fmt.Sprintf("⚠️  %s: %v\n", zoneName, err))
+	if len(query.Offsets) > 0 {
+		terms := make([]string, len(query.Offsets))
+		for i, offset := range query.Offsets {
+			terms[i] = offset.Text
+		}
+		result += fmt.Sprintf("\n\n%s %s", m.format.ShortDateTime(base), strings.Join(terms, " "))
+	}
+
+	switch {
+	case wall.Gap:
+		result += fmt.Sprintf("\n\n⚠️  %s does not exist in %s (DST gap, clocks spring forward); using %s",
+			m.format.ShortDateTime(wall.Wall),
+			sourceZone,
+			m.format.TimeInZone(wall.Instants[0]),
+		)
+	case wall.Overlap():
+		result += fmt.Sprintf("\n\n⚠️  %s occurs twice in %s (DST overlap, clocks fall back):",
+			m.format.ShortDateTime(wall.Wall),
+			sourceZone,
+		)
+		for _, instant := range wall.Instants {
+			shifted := instant
+			for _, offset := range resolved.WallOffsets {
+				shifted = offset.apply(shifted)
+			}
+			if len(targets) == 1 {
+				local := shifted.In(targets[0].Loc)
+				result += fmt.Sprintf("\n    %s  →  %s in %s",
+					m.format.TimeInZone(instant),
+					m.format.ShortDateTimeInZone(local),
+					targets[0].Zone,
+				)
+				continue
+			}
+
+			result += fmt.Sprintf("\n    %s", m.format.TimeInZone(instant))
+			for _, t := range targets {
+				local := shifted.In(t.Loc)
+				result += fmt.Sprintf("\n      →  %s in %s", m.format.ShortDateTimeInZone(local), t.Zone)
+			}
+		}
+	}
+
+	// Point out DST changes close to the converted time, when the offsets
+	// shown above are about to change or just did. A gap or overlap in the
+	// source zone has been explained already.
+	window := time.Duration(m.dstNoticeDays) * 24 * time.Hour
+	seen := map[string]bool{}
+	if wall.Gap || wall.Overlap() {
+		seen[sourceLoc.String()] = true
+	}
+	var notes []string
+	for _, zone := range append([]conversionTarget{{Zone: sourceZone, Loc: sourceLoc}}, targets...) {
+		if seen[zone.Loc.String()] {
 			continue
 		}
+		seen[zone.Loc.String()] = true
 
-		t := now.In(loc)
-		start := time.Date(t.Year(), t.Month(), t.Day(), 9, 0, 0, 0, loc)
-		end := time.Date(t.Year(), t.Month(), t.Day(), 17, 0, 0, 0, loc)
+		if transition, ok := nearestTransition(from, zone.Loc, window); ok {
+			notes = append(notes, "⚠️  "+describeTransition(transition, zone.Zone, from, m.format))
+		}
+	}
+	if len(notes) > 0 {
+		result += "\n\n" + strings.Join(notes, "\n")
+	}
+
+	return result, nil
+}
+
+// zoneDetails describes the zone of t: its abbreviation, UTC offset, and
+// offset difference from the zone of ref
+func zoneDetails(t, ref time.Time) []string {
+	_, offset := t.Zone()
+	_, refOffset := ref.Zone()
+	return []string{
+		zoneAbbreviation(t),
+		formatUTCOffset(t),
+		formatOffsetDifference(time.Duration(offset-refOffset) * time.Second),
+	}
+}
 
-		b.WriteString(fmt.Sprintf("%-15s: %s - %s\n",
-			zoneName,
-			start.In(time.Local).Format("3:04 PM"),
-			end.In(time.Local).Format("3:04 PM"),
-		))
+// previewConversion renders the live preview shown while a query is typed:
+// how the query is being read, then its result or what is still missing
+func (m model) previewConversion(input string) string {
+	if strings.TrimSpace(input) == "" {
+		return ""
 	}
 
+	var b strings.Builder
+	if reading := describeQuery(input); reading != "" {
+		b.WriteString(helpStyle.Render(reading))
+		b.WriteString("\n\n")
+	}
+
+	result, err := m.convert(input)
+	if err != nil {
+		b.WriteString(helpStyle.Render(err.Error()))
+		return b.String()
+	}
+	b.WriteString(resultStyle.Render(result))
 	return b.String()
 }
 
+// rerunConversion runs a changed version of the last conversion, putting
+// its text in the input so it can be edited further
+func (m *model) rerunConversion(query conversionQuery) {
+	result, err := m.convertQuery(query)
+	if err != nil {
+		m.convertResult = errorStyle.Render(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	m.lastQuery = query
+	m.convertResult = result
+	m.convertAnnouncement = m.announceQuery(query)
+	m.convertInput.SetValue(query.String())
+	m.convertInput.CursorEnd()
+	m.err = m.convertHistory.add(query.String())
+}
+
+// clockTargets returns the zones on the Clock view as conversion targets
+func (m model) clockTargets() []conversionTarget {
+	targets := make([]conversionTarget, len(m.zones))
+	for i, zone := range m.zones {
+		targets[i] = conversionTarget{Zone: zone.Name, Loc: zone.Location}
+	}
+	return targets
+}
+
 func main() {
-	p := tea.NewProgram(initialModel(), tea.WithAltScreen())
+	strict := flag.Bool("strict", false, "reject times that only parse by guessing, such as a lone '3'")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: aeon [--strict] [query]\n\n")
+		fmt.Fprintf(flag.CommandLine.Output(), "With a query, print the conversion and exit:\n  aeon 3pm NYC to Berlin\n\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	m := initialModel()
+	m.parseOpts.Strict = *strict
+
+	// One-shot mode for scripts: exit non-zero when the query fails
+	if flag.NArg() > 0 {
+		if m.err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", m.err)
+		}
+		result, err := m.convert(strings.Join(flag.Args(), " "))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println(result)
+		return
+	}
+
+	p := tea.NewProgram(m, tea.WithAltScreen())
 	if _, err := p.Run(); err != nil {
 		fmt.Printf("Error: %v\n", err)
 		os.Exit(1)
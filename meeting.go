@@ -0,0 +1,502 @@
+package main
+
+import (
+	"aeon/timezones"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// participant is one zone or person taking part in a meeting
+type participant struct {
+	Name  string
+	Loc   *time.Location
+	Hours workingHours
+}
+
+// interval is a span of time from Start up to, not including, End
+type interval struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Duration returns the length of the interval
+func (i interval) Duration() time.Duration {
+	return i.End.Sub(i.Start)
+}
+
+// meetingQuery is a Meeting view query: optional options and a colon, then
+// the participants: "60m next tuesday: NYC, London, Tokyo"
+type meetingQuery struct {
+	Participants []participant
+	// Length is how long the meeting runs. Zero accepts overlaps of any
+	// length and suggests one-hour slots.
+	Length time.Duration
+	// Days are the local dates to search. DaysText names them in results,
+	// or is empty for a single date shown as such.
+	Days     []time.Time
+	DaysText string
+	// Now is when the query was made. Earlier times are not offered; zero
+	// searches whole days.
+	Now time.Time
+	// Rotation is set for recurring meetings
+	Rotation *rotationSpec
+}
+
+// slotLength returns the length of slots to suggest or plan
+func (q meetingQuery) slotLength() time.Duration {
+	if q.Length > 0 {
+		return q.Length
+	}
+	return defaultMeetingLength
+}
+
+// meetingOptionsHint shows the forms of meeting options in errors
+const meetingOptionsHint = "Try: 60m next tuesday: NYC, London or weekly tuesday: NYC, London"
+
+// parseMeetingQuery parses a Meeting view query
+func (m model) parseMeetingQuery(input string) (meetingQuery, error) {
+	options, list := splitMeetingOptions(input)
+	participants, err := parseParticipants(list, m.workingHours)
+	if err != nil {
+		return meetingQuery{}, err
+	}
+
+	now := time.Now().In(time.Local)
+	query := meetingQuery{Participants: participants, Days: []time.Time{now}, DaysText: "today", Now: now}
+	if err := query.parseOptions(options, now); err != nil {
+		return meetingQuery{}, err
+	}
+	return query, nil
+}
+
+// parseOptions reads the options before the colon: an optional length,
+// then days or a rotation: "90m this week", "30m weekly tuesday"
+func (q *meetingQuery) parseOptions(options string, now time.Time) error {
+	words := strings.Fields(strings.ToLower(options))
+	if len(words) > 0 {
+		if length, err := time.ParseDuration(words[0]); err == nil {
+			if length < time.Minute || length > 24*time.Hour {
+				return fmt.Errorf("Meeting length must be between 1m and 24h")
+			}
+			q.Length, words = length, words[1:]
+		}
+	}
+	if len(words) == 0 {
+		return nil
+	}
+
+	rest := strings.Join(words, " ")
+	if spec, ok, err := parseRotation(rest, now); ok {
+		if err != nil {
+			return err
+		}
+		q.Rotation = &spec
+		return nil
+	}
+
+	days, text, err := parseMeetingDays(rest, now, now.Location())
+	if err != nil {
+		return err
+	}
+	q.Days, q.DaysText = days, text
+	return nil
+}
+
+// parseMeetingDays reads the days to search for a meeting: "today",
+// "tuesday", "next tuesday", "jan 20", or the remaining working days of
+// "this week" or all of "next week". Days and weekends are those of loc.
+func parseMeetingDays(text string, now time.Time, loc *time.Location) ([]time.Time, string, error) {
+	now = now.In(loc)
+	// The next working week starts on the first day after today that
+	// follows a weekend
+	start := weekStart(loc)
+	nextWeek := now.AddDate(0, 0, (int(start)-int(now.Weekday())+6)%7+1)
+	switch text {
+	case "today":
+		return []time.Time{now}, "today", nil
+	case "this week":
+		days := workingDaysBetween(now, nextWeek.AddDate(0, 0, -1), loc)
+		if len(days) == 0 {
+			return nil, "", fmt.Errorf("There are no working days left this week. Try: next week")
+		}
+		return days, text, nil
+	case "next week":
+		return workingDaysBetween(nextWeek, nextWeek.AddDate(0, 0, 6), loc), text, nil
+	}
+
+	if weekday, ok := weekdayNames[text]; ok {
+		// The coming one, which may be today
+		return []time.Time{now.AddDate(0, 0, (int(weekday)-int(now.Weekday())+7)%7)}, "", nil
+	}
+
+	parsed, err := parseTimeWithOptions(text, floatingTime(now), parseOptions{})
+	if err != nil || !parsed.AllDay() {
+		return nil, "", fmt.Errorf("Unknown meeting options '%s'. %s", text, meetingOptionsHint)
+	}
+	day := parsed.Time
+	return []time.Time{time.Date(day.Year(), day.Month(), day.Day(), 12, 0, 0, 0, loc)}, "", nil
+}
+
+// weekStart returns the first working day of the week in loc: Monday, or
+// Sunday where the weekend is Friday and Saturday
+func weekStart(loc *time.Location) time.Weekday {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if timezones.IsWeekend(loc, (day+6)%7) && !timezones.IsWeekend(loc, day) {
+			return day
+		}
+	}
+	return time.Monday
+}
+
+// workingDaysBetween returns the days from first to last, inclusive, that
+// are not on the weekend in loc
+func workingDaysBetween(first, last time.Time, loc *time.Location) []time.Time {
+	var days []time.Time
+	for day := first; calendarDays(day, last) >= 0; day = day.AddDate(0, 0, 1) {
+		if !timezones.IsWeekend(loc, day.Weekday()) {
+			days = append(days, day)
+		}
+	}
+	return days
+}
+
+// splitMeetingOptions splits a query at the colon ending its options. Colons
+// between digits belong to times in the participant list: "Berlin 8:30-17".
+func splitMeetingOptions(input string) (string, string) {
+	isDigit := func(i int) bool {
+		return i >= 0 && i < len(input) && input[i] >= '0' && input[i] <= '9'
+	}
+	for i := range input {
+		if input[i] == ':' && !(isDigit(i-1) && isDigit(i+1)) {
+			return strings.TrimSpace(input[:i]), input[i+1:]
+		}
+	}
+	return "", input
+}
+
+// parseParticipants resolves a comma-separated list of zones or people,
+// each optionally followed by working hours: "Berlin 8-16, NYC, priya"
+func parseParticipants(input string, config WorkingHoursConfig) ([]participant, error) {
+	var participants []participant
+	for _, entry := range strings.Split(input, ",") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+
+		p, err := parseParticipant(entry, config)
+		if err != nil {
+			return nil, err
+		}
+		participants = append(participants, p)
+	}
+
+	if len(participants) < 2 {
+		return nil, fmt.Errorf("Enter at least 2 zones")
+	}
+	return participants, nil
+}
+
+// parseParticipant reads one entry of a meeting query. Ranges of hours at
+// the end override the configured hours: "Los Angeles 8-12 13-17". A name
+// from the people section of the config stands for that person's zone.
+func parseParticipant(entry string, config WorkingHoursConfig) (participant, error) {
+	fields := strings.Fields(entry)
+	split := len(fields)
+	for split > 0 {
+		if _, ok := parseShift(fields[split-1]); !ok {
+			break
+		}
+		split--
+	}
+	name := strings.Join(fields[:split], " ")
+	if name == "" {
+		return participant{}, fmt.Errorf("Working hours '%s' need a zone", strings.TrimSpace(entry))
+	}
+
+	var p participant
+	var err error
+	if configured, person, ok := config.person(name); ok {
+		p.Name = configured
+		if p.Loc, err = resolveZone(person.Zone); err != nil {
+			return participant{}, fmt.Errorf("Zone of %s: %v", configured, err)
+		}
+		if person.Hours != "" {
+			p.Hours, err = parseWorkingHours(person.Hours)
+		} else {
+			p.Hours, err = config.zoneHours(person.Zone)
+		}
+	} else {
+		p.Name = zoneDisplayName(name)
+		if p.Loc, err = resolveZone(name); err != nil {
+			return participant{}, err
+		}
+		p.Hours, err = config.zoneHours(name)
+	}
+	if err != nil {
+		return participant{}, fmt.Errorf("Working hours of %s in the config: %v", p.Name, err)
+	}
+
+	if split < len(fields) {
+		if p.Hours, err = parseWorkingHours(strings.Join(fields[split:], " ")); err != nil {
+			return participant{}, err
+		}
+	}
+	return p, nil
+}
+
+// workingWindows returns p's working time that overlaps from..to, sorted
+// and with touching shifts joined. Shifts are placed by wall clock, so they
+// keep their local hours across DST changes.
+func workingWindows(p participant, from, to time.Time) []interval {
+	var windows []interval
+
+	// Start a day early to catch overnight shifts running into from
+	first := from.In(p.Loc)
+	day := time.Date(first.Year(), first.Month(), first.Day()-1, 0, 0, 0, 0, time.UTC)
+	for ; resolveWallClock(day, p.Loc).Instants[0].Before(to); day = day.AddDate(0, 0, 1) {
+		for _, s := range p.Hours {
+			start := resolveWallClock(day.Add(s.Start), p.Loc).Instants[0]
+			end := resolveWallClock(day.Add(s.End), p.Loc).Instants[0]
+			if start.Before(to) && end.After(from) {
+				windows = append(windows, interval{Start: start, End: end})
+			}
+		}
+	}
+
+	sort.Slice(windows, func(i, j int) bool {
+		return windows[i].Start.Before(windows[j].Start)
+	})
+	merged := windows[:0]
+	for _, window := range windows {
+		if n := len(merged); n > 0 && !window.Start.After(merged[n-1].End) {
+			if window.End.After(merged[n-1].End) {
+				merged[n-1].End = window.End
+			}
+			continue
+		}
+		merged = append(merged, window)
+	}
+	return merged
+}
+
+// intersectIntervals returns the spans covered by both a and b, which must
+// each be sorted and non-overlapping
+func intersectIntervals(a, b []interval) []interval {
+	var result []interval
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		start, end := a[i].Start, a[i].End
+		if b[j].Start.After(start) {
+			start = b[j].Start
+		}
+		if b[j].End.Before(end) {
+			end = b[j].End
+		}
+		if start.Before(end) {
+			result = append(result, interval{Start: start, End: end})
+		}
+
+		if a[i].End.Before(b[j].End) {
+			i++
+		} else {
+			j++
+		}
+	}
+	return result
+}
+
+// meetingOverlaps returns the spans within from..to when every participant
+// is in working hours. Spans are kept whole when they cross either bound.
+func meetingOverlaps(participants []participant, from, to time.Time) []interval {
+	var overlaps []interval
+	for i, p := range participants {
+		windows := workingWindows(p, from, to)
+		if i == 0 {
+			overlaps = windows
+		} else {
+			overlaps = intersectIntervals(overlaps, windows)
+		}
+	}
+	return overlaps
+}
+
+// runMeeting answers the query typed in the Meeting view. A successful
+// query is kept for the timeline, the announcement and rotation export, and
+// saved to history.
+func (m *model) runMeeting(input string) {
+	m.lastMeeting, m.meetingAnnouncement, m.meetingPlan = meetingQuery{}, "", nil
+
+	query, err := m.parseMeetingQuery(input)
+	if err != nil {
+		m.meetingResult = errorStyle.Render(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	if query.Rotation != nil {
+		m.meetingPlan = m.planQueryRotation(query)
+		m.meetingResult = m.renderRotation(query.Participants, *query.Rotation, m.meetingPlan, query.slotLength())
+	} else {
+		m.meetingResult = m.renderMeeting(query)
+	}
+	m.lastMeeting = query
+	m.meetingAnnouncement = m.announceMeeting(query)
+	m.err = m.meetingHistory.add(input)
+}
+
+// renderMeeting finds the times on the query's days when all participants
+// are in working hours and shows them in each participant's time
+func (m model) renderMeeting(query meetingQuery) string {
+	participants := query.Participants
+
+	when := query.DaysText
+	if when == "" {
+		when = "on " + m.format.Date(query.Days[0])
+	}
+
+	var b strings.Builder
+	overlaps := query.overlaps()
+	if len(overlaps) == 0 {
+		length := ""
+		if query.Length > 0 {
+			length = " for " + formatShortDuration(query.Length)
+		}
+		fmt.Fprintf(&b, "No overlap: there is no time %s when everyone is within working hours%s.\n\n", when, length)
+
+		// Listing every day of a week would bury the suggestions
+		if len(query.Days) == 1 {
+			from, to := dayBounds(query.Days[0], time.Local)
+			b.WriteString("Working hours in your time:\n\n")
+
+			var rows [][]string
+			for _, p := range participants {
+				for _, window := range workingWindows(p, from, to) {
+					rows = append(rows, m.meetingRow(p.Name, window, time.Local))
+				}
+			}
+			b.WriteString(formatTable(rows, "  "))
+			b.WriteString("\n\n")
+		}
+
+		b.WriteString(m.renderSuggestions(participants, query.suggestions(m.meetingConfig)))
+		return b.String()
+	}
+
+	if query.Length > 0 {
+		fmt.Fprintf(&b, "Everyone is within working hours for at least %s %s:\n", formatShortDuration(query.Length), when)
+	} else {
+		fmt.Fprintf(&b, "Everyone is within working hours %s:\n", when)
+	}
+	for _, overlap := range overlaps {
+		fmt.Fprintf(&b, "\n  %s\n", formatHumanDuration(overlap.Duration()))
+
+		rows := make([][]string, len(participants))
+		for i, p := range participants {
+			row := m.meetingRow(p.Name, overlap, p.Loc)
+			rows[i] = append(row, "works "+m.format.Shifts(p.Hours))
+		}
+		b.WriteString(formatTable(rows, "    "))
+		b.WriteString("\n")
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// overlaps returns the spans on the query's days when everyone is within
+// working hours, keeping those at least Length long
+func (q meetingQuery) overlaps() []interval {
+	var found []interval
+	for _, r := range q.dayRanges() {
+		for _, overlap := range meetingOverlaps(q.Participants, r.Start, r.End) {
+			// Only what is left of an overlap under way counts
+			if overlap.Start.Before(r.Start) {
+				overlap.Start = r.Start
+			}
+			// An overlap across midnight is found whole the day before
+			if n := len(found); n > 0 && overlap.Start.Before(found[n-1].End) {
+				continue
+			}
+			if overlap.Duration() < q.Length {
+				continue
+			}
+			found = append(found, overlap)
+		}
+	}
+	return found
+}
+
+// suggestions ranks slots on the query's days
+func (q meetingQuery) suggestions(config MeetingConfig) []suggestion {
+	return suggestSlots(q.Participants, q.dayRanges(), q.slotLength(), config)
+}
+
+// dayRanges returns the spans of the query's local days, with today's
+// starting at the first slotStep of the day's grid not before Now. Days
+// already over are left out.
+func (q meetingQuery) dayRanges() []interval {
+	var ranges []interval
+	for _, day := range q.Days {
+		from, to := dayBounds(day, time.Local)
+		if q.Now.After(from) {
+			steps := (q.Now.Sub(from) + slotStep - 1) / slotStep
+			from = from.Add(steps * slotStep)
+		}
+		if from.Before(to) {
+			ranges = append(ranges, interval{Start: from, End: to})
+		}
+	}
+	return ranges
+}
+
+// meetingRow renders a span in loc as a table row: name, times, date and
+// zone abbreviation
+func (m model) meetingRow(name string, span interval, loc *time.Location) []string {
+	start, end := span.Start.In(loc), span.End.In(loc)
+	return []string{
+		name,
+		m.format.Time(start) + " - " + m.format.Time(end),
+		m.format.ShortDate(start),
+		zoneAbbreviation(start),
+	}
+}
+
+// announceMeeting renders the first overlap, cut to the meeting length when
+// one is given, or the best suggestion when there is none, as one line to
+// paste into a message: "2:00 PM - 5:00 PM GMT / 9:00 AM - 12:00 PM ET".
+// Each zone is listed once, with dates that differ from the first
+// participant's marked. Rotations have no such form.
+func (m model) announceMeeting(query meetingQuery) string {
+	if query.Rotation != nil {
+		return ""
+	}
+
+	participants := query.Participants
+	var overlap interval
+	if overlaps := query.overlaps(); len(overlaps) > 0 {
+		overlap = overlaps[0]
+		if query.Length > 0 {
+			overlap.End = overlap.Start.Add(query.Length)
+		}
+	} else if suggestions := query.suggestions(m.meetingConfig); len(suggestions) > 0 {
+		overlap = suggestions[0].Slot
+	} else {
+		return ""
+	}
+
+	reference := overlap.Start.In(participants[0].Loc)
+	seen := map[string]bool{}
+	var parts []string
+	for _, p := range participants {
+		if seen[p.Loc.String()] {
+			continue
+		}
+		seen[p.Loc.String()] = true
+
+		start, end := overlap.Start.In(p.Loc), overlap.End.In(p.Loc)
+		part := m.format.Time(start) + " - " + m.format.Time(end) + " " + announcementZone(start)
+		if days := calendarDays(reference, start); days != 0 {
+			part += fmt.Sprintf(" (%s)", formatDayDelta(days))
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, " / ")
+}
@@ -0,0 +1,403 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestIntersectIntervals(t *testing.T) {
+	at := func(hour int) time.Time {
+		return time.Date(2026, 1, 20, 0, 0, 0, 0, time.UTC).Add(time.Duration(hour) * time.Hour)
+	}
+	span := func(start, end int) interval {
+		return interval{Start: at(start), End: at(end)}
+	}
+
+	tests := []struct {
+		name string
+		a, b []interval
+		want []interval
+	}{
+		{"partial", []interval{span(9, 17)}, []interval{span(14, 22)}, []interval{span(14, 17)}},
+		{"contained", []interval{span(9, 17)}, []interval{span(10, 12)}, []interval{span(10, 12)}},
+		{"disjoint", []interval{span(0, 8)}, []interval{span(14, 22)}, nil},
+		{"touching", []interval{span(9, 14)}, []interval{span(14, 22)}, nil},
+		{
+			name: "several",
+			a:    []interval{span(0, 8), span(24, 32)},
+			b:    []interval{span(6, 26), span(30, 40)},
+			want: []interval{span(6, 8), span(24, 26), span(30, 32)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := intersectIntervals(tt.a, tt.b)
+			if len(got) != len(tt.want) {
+				t.Fatalf("intersectIntervals() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if !got[i].Start.Equal(tt.want[i].Start) || !got[i].End.Equal(tt.want[i].End) {
+					t.Errorf("intersectIntervals()[%d] = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestMeetingOverlaps(t *testing.T) {
+	load := func(name string) participant {
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			t.Fatalf("LoadLocation(%q): %v", name, err)
+		}
+		return participant{Name: name, Loc: loc, Hours: defaultWorkingHours}
+	}
+	nyc, london, tokyo := load("America/New_York"), load("Europe/London"), load("Asia/Tokyo")
+	berlin := load("Europe/Berlin")
+	withHours := func(p participant, text string) participant {
+		hours, err := parseWorkingHours(text)
+		if err != nil {
+			t.Fatalf("parseWorkingHours(%q): %v", text, err)
+		}
+		p.Hours = hours
+		return p
+	}
+
+	tests := []struct {
+		name         string
+		participants []participant
+		day          time.Time
+		want         []string
+	}{
+		{
+			name:         "winter",
+			participants: []participant{nyc, london},
+			day:          time.Date(2026, 1, 20, 0, 0, 0, 0, time.UTC),
+			want:         []string{"14:00-17:00"},
+		},
+		{
+			// London has moved to BST, NYC is still on EST
+			name:         "between DST changes",
+			participants: []participant{london, nyc},
+			day:          time.Date(2026, 3, 30, 0, 0, 0, 0, time.UTC),
+			want:         []string{"13:00-16:00"},
+		},
+		{
+			name:         "split shift",
+			participants: []participant{withHours(london, "8-12 13-17"), nyc},
+			day:          time.Date(2026, 1, 20, 0, 0, 0, 0, time.UTC),
+			want:         []string{"14:00-17:00"},
+		},
+		{
+			name:         "split shift around the overlap",
+			participants: []participant{withHours(london, "9-15 16-18"), nyc},
+			day:          time.Date(2026, 1, 20, 0, 0, 0, 0, time.UTC),
+			want:         []string{"14:00-15:00", "16:00-18:00"},
+		},
+		{
+			// Tokyo's night shift from the 19th runs into the 20th
+			name:         "overnight shift",
+			participants: []participant{withHours(tokyo, "22-6"), london},
+			day:          time.Date(2026, 1, 20, 0, 0, 0, 0, time.UTC),
+			want:         []string{"13:00-17:00"},
+		},
+		{
+			name:         "no overlap",
+			participants: []participant{nyc, tokyo},
+			day:          time.Date(2026, 1, 20, 0, 0, 0, 0, time.UTC),
+			want:         nil,
+		},
+		{
+			name:         "three zones",
+			participants: []participant{london, berlin, nyc},
+			day:          time.Date(2026, 1, 20, 0, 0, 0, 0, time.UTC),
+			want:         []string{"14:00-16:00"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			overlaps := meetingOverlaps(tt.participants, tt.day, tt.day.Add(24*time.Hour))
+			var got []string
+			for _, overlap := range overlaps {
+				got = append(got, overlap.Start.UTC().Format("15:04")+"-"+overlap.End.UTC().Format("15:04"))
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("meetingOverlaps() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("meetingOverlaps()[%d] = %s, want %s", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseMeetingOptions(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("tzdata not available: %v", err)
+	}
+	// A Wednesday
+	now := time.Date(2026, 1, 21, 12, 0, 0, 0, berlin)
+
+	tests := []struct {
+		options      string
+		wantLength   time.Duration
+		wantDays     []string
+		wantText     string
+		wantRotation bool
+		wantError    bool
+	}{
+		{options: "", wantDays: []string{"2026-01-21"}, wantText: "today"},
+		{options: "60m", wantLength: time.Hour, wantDays: []string{"2026-01-21"}, wantText: "today"},
+		{options: "1h30m tomorrow", wantLength: 90 * time.Minute, wantDays: []string{"2026-01-22"}},
+		{options: "next tuesday", wantDays: []string{"2026-01-27"}},
+		{options: "wednesday", wantDays: []string{"2026-01-21"}},
+		{options: "60m 2026-02-03", wantLength: time.Hour, wantDays: []string{"2026-02-03"}},
+		{options: "90m this week", wantLength: 90 * time.Minute, wantDays: []string{"2026-01-21", "2026-01-22", "2026-01-23"}, wantText: "this week"},
+		{options: "next week", wantDays: []string{"2026-01-26", "2026-01-27", "2026-01-28", "2026-01-29", "2026-01-30"}, wantText: "next week"},
+		{options: "30m weekly tuesday", wantLength: 30 * time.Minute, wantDays: []string{"2026-01-21"}, wantText: "today", wantRotation: true},
+		{options: "tomorrow 3pm", wantError: true},
+		{options: "25h", wantError: true},
+		{options: "sometime", wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.options, func(t *testing.T) {
+			query := meetingQuery{Days: []time.Time{now}, DaysText: "today"}
+			err := query.parseOptions(tt.options, now)
+			if tt.wantError {
+				if err == nil {
+					t.Errorf("parseOptions(%q) = %+v, want error", tt.options, query)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseOptions(%q) error: %v", tt.options, err)
+			}
+
+			if query.Length != tt.wantLength {
+				t.Errorf("Length = %v, want %v", query.Length, tt.wantLength)
+			}
+			if query.DaysText != tt.wantText {
+				t.Errorf("DaysText = %q, want %q", query.DaysText, tt.wantText)
+			}
+			if (query.Rotation != nil) != tt.wantRotation {
+				t.Errorf("Rotation = %v, want rotation %v", query.Rotation, tt.wantRotation)
+			}
+			var days []string
+			for _, day := range query.Days {
+				days = append(days, day.Format("2006-01-02"))
+			}
+			if strings.Join(days, " ") != strings.Join(tt.wantDays, " ") {
+				t.Errorf("Days = %v, want %v", days, tt.wantDays)
+			}
+		})
+	}
+}
+
+// setLocalZone makes the named zone the user's own for the test
+func setLocalZone(t *testing.T, name string) {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Skipf("tzdata not available: %v", err)
+	}
+
+	local := time.Local
+	time.Local = loc
+	t.Cleanup(func() {
+		time.Local = local
+	})
+}
+
+func TestParseMeetingDaysWeekends(t *testing.T) {
+	riyadh, err := time.LoadLocation("Asia/Riyadh")
+	if err != nil {
+		t.Skipf("tzdata not available: %v", err)
+	}
+	sunday := time.Date(2026, 1, 18, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		text      string
+		now       time.Time
+		loc       *time.Location
+		wantDays  []string
+		wantError bool
+	}{
+		{name: "weekend left", text: "this week", now: sunday, loc: time.UTC, wantError: true},
+		{name: "monday to friday", text: "next week", now: sunday, loc: time.UTC, wantDays: []string{"19", "20", "21", "22", "23"}},
+		{name: "sunday to thursday", text: "this week", now: sunday, loc: riyadh, wantDays: []string{"18", "19", "20", "21", "22"}},
+		{name: "the sunday after", text: "next week", now: sunday, loc: riyadh, wantDays: []string{"25", "26", "27", "28", "29"}},
+		{name: "thursday", text: "this week", now: sunday.AddDate(0, 0, -3), loc: riyadh, wantDays: []string{"15"}},
+		{name: "friday", text: "this week", now: sunday.AddDate(0, 0, -2), loc: riyadh, wantError: true},
+		{name: "friday, next week", text: "next week", now: sunday.AddDate(0, 0, -2), loc: riyadh, wantDays: []string{"18", "19", "20", "21", "22"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, err := parseMeetingDays(tt.text, tt.now, tt.loc)
+			if tt.wantError {
+				if err == nil {
+					t.Errorf("parseMeetingDays(%q) = %v, want error", tt.text, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseMeetingDays(%q) error: %v", tt.text, err)
+			}
+
+			var days []string
+			for _, day := range got {
+				days = append(days, day.Format("02"))
+			}
+			if strings.Join(days, " ") != strings.Join(tt.wantDays, " ") {
+				t.Errorf("parseMeetingDays(%q) days = %v, want %v", tt.text, days, tt.wantDays)
+			}
+		})
+	}
+
+	t.Run("local zone", func(t *testing.T) {
+		setLocalZone(t, "Asia/Riyadh")
+		got, _, err := parseMeetingDays("this week", sunday, time.Local)
+		if err != nil {
+			t.Fatalf("parseMeetingDays error: %v", err)
+		}
+		if len(got) != 5 || got[0].Weekday() != time.Sunday {
+			t.Errorf("parseMeetingDays = %v, want Sunday to Thursday", got)
+		}
+	})
+}
+
+func TestMeetingQueryOverlaps(t *testing.T) {
+	setLocalZone(t, "UTC")
+	nyc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Working hours overlap for two hours a day
+	participants := []participant{
+		{Name: "NYC", Loc: nyc, Hours: defaultWorkingHours},
+		{Name: "Berlin", Loc: berlin, Hours: defaultWorkingHours},
+	}
+	days := []time.Time{
+		time.Date(2026, 1, 20, 12, 0, 0, 0, time.Local),
+		time.Date(2026, 1, 21, 12, 0, 0, 0, time.Local),
+	}
+
+	// Midway through the first overlap, 14:00 - 16:00 UTC
+	now := time.Date(2026, 1, 20, 15, 10, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		length time.Duration
+		now    time.Time
+		want   int
+	}{
+		{"any length", 0, time.Time{}, 2},
+		{"fits", 2 * time.Hour, time.Time{}, 2},
+		{"too long", 2*time.Hour + time.Minute, time.Time{}, 0},
+		{"rest of today", 0, now, 2},
+		{"too late today", 2 * time.Hour, now, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := meetingQuery{Participants: participants, Length: tt.length, Days: days, Now: tt.now}
+			got := query.overlaps()
+			if len(got) != tt.want {
+				t.Errorf("overlaps() = %v, want %d overlaps", got, tt.want)
+			}
+			if len(got) > 0 && got[0].Start.Before(tt.now) {
+				t.Errorf("overlaps() starts at %v, before now", got[0].Start)
+			}
+
+			// Suggested slots have the meeting's length, start on the
+			// half hour and haven't passed
+			for _, s := range query.suggestions(MeetingConfig{}) {
+				if s.Slot.Duration() != query.slotLength() {
+					t.Fatalf("suggested slot %v, want %v long", s.Slot, query.slotLength())
+				}
+				if s.Slot.Start.Before(tt.now) || s.Slot.Start.Minute()%30 != 0 {
+					t.Fatalf("suggested slot %v, want one on the half hour after %v", s.Slot, tt.now)
+				}
+			}
+		})
+	}
+}
+
+func TestMeetingQueryPartwayThroughDay(t *testing.T) {
+	setLocalZone(t, "UTC")
+	nyc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	day := []time.Time{time.Date(2026, 1, 20, 0, 0, 0, 0, time.Local)}
+	// Midway through NYC and Berlin's overlap, 14:00 - 16:00 UTC
+	now := time.Date(2026, 1, 20, 15, 10, 0, 0, time.UTC)
+
+	query := meetingQuery{
+		Participants: []participant{
+			{Name: "NYC", Loc: nyc, Hours: defaultWorkingHours},
+			{Name: "Berlin", Loc: berlin, Hours: defaultWorkingHours},
+		},
+		Days: day,
+		Now:  now,
+	}
+	want := []interval{{Start: time.Date(2026, 1, 20, 15, 30, 0, 0, time.UTC), End: time.Date(2026, 1, 20, 16, 0, 0, 0, time.UTC)}}
+	if got := query.overlaps(); len(got) != 1 || !got[0].Start.Equal(want[0].Start) || !got[0].End.Equal(want[0].End) {
+		t.Errorf("overlaps() = %v, want the rest of the overlap under way, %v", got, want)
+	}
+
+	// NYC and Tokyo don't overlap, so slots are suggested instead
+	query.Participants = []participant{
+		{Name: "NYC", Loc: nyc, Hours: defaultWorkingHours},
+		{Name: "Tokyo", Loc: tokyo, Hours: defaultWorkingHours},
+	}
+	suggestions := query.suggestions(MeetingConfig{})
+	if len(suggestions) == 0 {
+		t.Fatal("suggestions() found no slots for the rest of the day")
+	}
+	for _, s := range suggestions {
+		if s.Slot.Start.Before(now) || s.Slot.Start.Minute()%30 != 0 {
+			t.Errorf("suggested slot %v, want one on the half hour after %v", s.Slot, now)
+		}
+	}
+}
+
+func TestSplitMeetingOptions(t *testing.T) {
+	tests := []struct {
+		input       string
+		wantOptions string
+		wantList    string
+	}{
+		{"NYC, London", "", "NYC, London"},
+		{"weekly tuesday: NYC, London", "weekly tuesday", " NYC, London"},
+		{"Berlin 8:30-17, NYC", "", "Berlin 8:30-17, NYC"},
+		{"weekly: Berlin 8:30-17, NYC", "weekly", " Berlin 8:30-17, NYC"},
+	}
+
+	for _, tt := range tests {
+		options, list := splitMeetingOptions(tt.input)
+		if options != tt.wantOptions || list != tt.wantList {
+			t.Errorf("splitMeetingOptions(%q) = %q, %q, want %q, %q", tt.input, options, list, tt.wantOptions, tt.wantList)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"aeon/timezones"
 	"fmt"
 	"regexp"
 	"strconv"
@@ -8,37 +9,263 @@ import (
 	"time"
 )
 
+// weekdayNames maps full and abbreviated day names to weekdays
+var weekdayNames = map[string]time.Weekday{
+	"sunday": time.Sunday, "sun": time.Sunday,
+	"monday": time.Monday, "mon": time.Monday,
+	"tuesday": time.Tuesday, "tue": time.Tuesday, "tues": time.Tuesday,
+	"wednesday": time.Wednesday, "wed": time.Wednesday,
+	"thursday": time.Thursday, "thu": time.Thursday, "thur": time.Thursday, "thurs": time.Thursday,
+	"friday": time.Friday, "fri": time.Friday,
+	"saturday": time.Saturday, "sat": time.Saturday,
+}
+
 // ParsedTime represents a parsed time with its components
 type ParsedTime struct {
 	Time     time.Time
 	Original string
+	// Exact is set when Time is a specific instant ("now", "in 2 hours")
+	// rather than a wall-clock time in the reference zone
+	Exact bool
+	// Approximate is set for hedged times: "noonish", "around 3"
+	Approximate bool
+	// Precision is the finest unit the expression specified
+	Precision Precision
+	// DefaultedDate is set when the expression named a time of day without
+	// a date ("3pm", "noon"), so the date was taken from the reference time
+	DefaultedDate bool
+	// DefaultedTime is set when the expression named a date without a time
+	// of day ("tomorrow", "jan 20"), so the time was filled in
+	DefaultedTime bool
+	// RolledDays is how many days a time-only expression was moved off the
+	// reference date by rollover or a "next"/"last" keyword
+	RolledDays int
+	// Guess describes a lenient reading the parser had to make, such as a
+	// lone "3" taken as an hour. Strict mode rejects results with a guess.
+	Guess string
+}
+
+// Precision is the finest unit a parsed time was specified to. The zero
+// value is full precision, so expressions derived from the current instant
+// ("now", "in 2 hours") need no special handling.
+type Precision int
+
+const (
+	PrecisionSecond Precision = iota // "now", "in 2 hours"
+	PrecisionMinute                  // "3:30pm", "quarter past 3"
+	PrecisionHour                    // "3pm", "noon"
+	PrecisionDate                    // "tomorrow", "jan 20"
+)
+
+// String returns the name of the precision
+func (p Precision) String() string {
+	switch p {
+	case PrecisionMinute:
+		return "minute"
+	case PrecisionHour:
+		return "hour"
+	case PrecisionDate:
+		return "date"
+	}
+	return "second"
+}
+
+// AllDay reports whether the expression named a whole day rather than a
+// time: "tomorrow" rather than "tomorrow 9am"
+func (p ParsedTime) AllDay() bool {
+	return p.Precision == PrecisionDate
+}
+
+// parseOptions tune how ambiguous input is resolved
+type parseOptions struct {
+	// Rollover moves time-only expressions that have already passed today
+	// to their next occurrence
+	Rollover bool
+	// Strict rejects input that only parses by guessing, so scripts fail
+	// loudly instead of converting garbage
+	Strict bool
 }
 
 // parseTimeWithContext parses a time string with support for:
 // - Relative times: "tomorrow 10am", "in 2 hours", "next monday 3pm"
+// - Month-relative: "first monday of next month", "the 15th 10am", "end of month"
 // - Date support: "2026-01-20 3pm", "Jan 20 3pm"
+// - ISO week dates: "2026-W12-1 10am", "week 12 monday 3pm", "W12 fri"
 // - Natural language: "noon", "midnight", "now"
 // - Traditional: "3pm", "15:04"
+// - Spoken English: "quarter past 3", "ten to six", "in two hours"
+// - Approximate: "noonish", "around 3" (flagged as Approximate)
+// - Rollover keywords: "next 3pm", "last 3pm"
+// A time may also come before its date: "9am tomorrow"
 func parseTimeWithContext(input string, refTime time.Time) (ParsedTime, error) {
-	input = strings.ToLower(strings.TrimSpace(input))
+	return parseTimeWithOptions(input, refTime, parseOptions{})
+}
+
+// parseTimeWithOptions is parseTimeWithContext with tunable resolution
+func parseTimeWithOptions(input string, refTime time.Time, opts parseOptions) (ParsedTime, error) {
+	original := strings.ToLower(strings.TrimSpace(input))
 
-	if input == "" {
+	if original == "" {
 		return ParsedTime{}, fmt.Errorf("empty time string")
 	}
 
+	input, approximate := stripApproximation(original)
+	input = replaceNumberWords(input)
+
+	// "next 3pm" and "last 3pm" pick the occurrence after or before now,
+	// whatever the rollover preference says
+	direction := 0
+	if opts.Rollover {
+		direction = 1
+	}
+	if keyword, rest, ok := strings.Cut(input, " "); ok && (keyword == "next" || keyword == "last") {
+		if result, err := parseTimeExpression(rest, refTime); err == nil && result.DefaultedDate {
+			input = rest
+			direction = 1
+			if keyword == "last" {
+				direction = -1
+			}
+		}
+	}
+
+	result, err := parseTimeExpression(input, refTime)
+	if err != nil {
+		// Time before the date: "9am tomorrow", "3pm next monday"
+		if first, rest, ok := strings.Cut(input, " "); ok {
+			if _, simpleErr := parseSimpleTime(first, refTime); simpleErr == nil {
+				result, err = parseTimeExpression(rest+" "+first, refTime)
+			}
+		}
+	}
+	if err != nil {
+		return ParsedTime{}, fmt.Errorf("could not parse time: %s", original)
+	}
+
+	if opts.Strict && result.Guess != "" {
+		return ParsedTime{}, fmt.Errorf("ambiguous: %s", result.Guess)
+	}
+
+	result.Original = original
+	result.Approximate = approximate
+	if result.DefaultedDate {
+		result = rollTimeOnly(result, refTime, direction)
+	}
+	return result, nil
+}
+
+// rollTimeOnly moves a time-only result to its next occurrence after
+// refTime (direction 1) or its last occurrence before it (direction -1)
+func rollTimeOnly(result ParsedTime, refTime time.Time, direction int) ParsedTime {
+	switch {
+	case direction > 0 && !result.Time.After(refTime):
+		result.Time = result.Time.AddDate(0, 0, 1)
+		result.RolledDays = 1
+	case direction < 0 && !result.Time.Before(refTime):
+		result.Time = result.Time.AddDate(0, 0, -1)
+		result.RolledDays = -1
+	}
+	return result
+}
+
+// approximateWords hedge the time that follows them: "around 3"
+var approximateWords = map[string]bool{
+	"around":        true,
+	"about":         true,
+	"approximately": true,
+	"approx":        true,
+	"roughly":       true,
+	"circa":         true,
+}
+
+// stripApproximation removes hedges such as "around", "~" and "-ish" from
+// input and reports whether any were found
+func stripApproximation(input string) (string, bool) {
+	var words []string
+	approximate := false
+
+	for _, word := range strings.Fields(input) {
+		if approximateWords[word] {
+			approximate = true
+			continue
+		}
+		if trimmed := strings.TrimPrefix(word, "~"); trimmed != word {
+			approximate = true
+			word = trimmed
+		}
+		if trimmed := strings.TrimSuffix(strings.TrimSuffix(word, "ish"), "-"); trimmed != word && trimmed != "" {
+			approximate = true
+			word = trimmed
+		}
+		if word != "" {
+			words = append(words, word)
+		}
+	}
+
+	return strings.Join(words, " "), approximate
+}
+
+// numberWords maps spoken numbers to their values
+var numberWords = map[string]int{
+	"zero": 0, "one": 1, "two": 2, "three": 3, "four": 4, "five": 5,
+	"six": 6, "seven": 7, "eight": 8, "nine": 9, "ten": 10,
+	"eleven": 11, "twelve": 12, "thirteen": 13, "fourteen": 14, "fifteen": 15,
+	"sixteen": 16, "seventeen": 17, "eighteen": 18, "nineteen": 19,
+	"twenty": 20, "thirty": 30, "forty": 40, "fifty": 50,
+}
+
+// replaceNumberWords turns spoken numbers into digits, including compounds
+// such as "twenty five" and "twenty-five": "in two hours" -> "in 2 hours"
+func replaceNumberWords(input string) string {
+	words := strings.Fields(strings.ReplaceAll(input, "-", " - "))
+	var out []string
+
+	for i := 0; i < len(words); i++ {
+		value, ok := numberWords[words[i]]
+		if !ok {
+			out = append(out, words[i])
+			continue
+		}
+
+		// "twenty five" or "twenty - five"
+		if value >= 20 {
+			next := i + 1
+			if next < len(words) && words[next] == "-" {
+				next++
+			}
+			if next < len(words) {
+				if unit, ok := numberWords[words[next]]; ok && unit > 0 && unit < 10 {
+					value += unit
+					i = next
+				}
+			}
+		}
+
+		out = append(out, strconv.Itoa(value))
+	}
+
+	// Put back hyphens that were not part of a number ("2026-01-20")
+	return strings.ReplaceAll(strings.Join(out, " "), " - ", "-")
+}
+
+// parseTimeExpression tries each parser in turn on a normalized input
+func parseTimeExpression(input string, refTime time.Time) (ParsedTime, error) {
 	// Natural language shortcuts
 	switch input {
 	case "now":
-		return ParsedTime{Time: refTime, Original: input}, nil
+		return ParsedTime{Time: refTime, Original: input, Exact: true}, nil
 	case "noon":
 		return ParsedTime{
-			Time:     time.Date(refTime.Year(), refTime.Month(), refTime.Day(), 12, 0, 0, 0, refTime.Location()),
-			Original: input,
+			Time:          time.Date(refTime.Year(), refTime.Month(), refTime.Day(), 12, 0, 0, 0, refTime.Location()),
+			Original:      input,
+			Precision:     PrecisionHour,
+			DefaultedDate: true,
 		}, nil
 	case "midnight":
 		return ParsedTime{
-			Time:     time.Date(refTime.Year(), refTime.Month(), refTime.Day(), 0, 0, 0, 0, refTime.Location()),
-			Original: input,
+			Time:          time.Date(refTime.Year(), refTime.Month(), refTime.Day(), 0, 0, 0, 0, refTime.Location()),
+			Original:      input,
+			Precision:     PrecisionHour,
+			DefaultedDate: true,
 		}, nil
 	}
 
@@ -47,6 +274,11 @@ func parseTimeWithContext(input string, refTime time.Time) (ParsedTime, error) {
 		return result, nil
 	}
 
+	// Try month-relative parsing ("first monday of next month", "end of month")
+	if result, err := parseMonthRelative(input, refTime); err == nil {
+		return result, nil
+	}
+
 	// Try date with time parsing
 	if result, err := parseDateWithTime(input, refTime); err == nil {
 		return result, nil
@@ -54,6 +286,7 @@ func parseTimeWithContext(input string, refTime time.Time) (ParsedTime, error) {
 
 	// Fall back to simple time parsing (original behavior)
 	if result, err := parseSimpleTime(input, refTime); err == nil {
+		result.DefaultedDate = true
 		return result, nil
 	}
 
@@ -62,6 +295,11 @@ func parseTimeWithContext(input string, refTime time.Time) (ParsedTime, error) {
 
 // parseRelativeTime handles relative time expressions
 func parseRelativeTime(input string, refTime time.Time) (ParsedTime, error) {
+	// Business days: "in 3 business days 5pm", "next business day 9am", "2 working days ago"
+	if result, err := parseBusinessDays(input, refTime); err == nil {
+		return result, nil
+	}
+
 	// "in X hours/minutes/days"
 	inPattern := regexp.MustCompile(`^in\s+(\d+)\s+(hour|hours|minute|minutes|min|mins|day|days)$`)
 	if matches := inPattern.FindStringSubmatch(input); matches != nil {
@@ -79,7 +317,7 @@ func parseRelativeTime(input string, refTime time.Time) (ParsedTime, error) {
 		}
 
 		result := refTime.Add(duration)
-		return ParsedTime{Time: result, Original: input}, nil
+		return ParsedTime{Time: result, Original: input, Exact: true}, nil
 	}
 
 	// "tomorrow [time]" or just "tomorrow"
@@ -87,18 +325,8 @@ func parseRelativeTime(input string, refTime time.Time) (ParsedTime, error) {
 		tomorrow := refTime.AddDate(0, 0, 1)
 		remaining := strings.TrimSpace(strings.TrimPrefix(input, "tomorrow"))
 
-		if remaining == "" {
-			// Just "tomorrow" - use 9am as default
-			result := time.Date(tomorrow.Year(), tomorrow.Month(), tomorrow.Day(), 9, 0, 0, 0, refTime.Location())
-			return ParsedTime{Time: result, Original: input}, nil
-		}
-
-		// "tomorrow 3pm"
-		timeResult, err := parseSimpleTime(remaining, tomorrow)
-		if err != nil {
-			return ParsedTime{}, err
-		}
-		return ParsedTime{Time: timeResult.Time, Original: input}, nil
+		// "tomorrow 3pm", or just "tomorrow" with 9am as default
+		return dateWithOptionalTime(tomorrow, remaining, input)
 	}
 
 	// "yesterday [time]" or just "yesterday"
@@ -106,35 +334,16 @@ func parseRelativeTime(input string, refTime time.Time) (ParsedTime, error) {
 		yesterday := refTime.AddDate(0, 0, -1)
 		remaining := strings.TrimSpace(strings.TrimPrefix(input, "yesterday"))
 
-		if remaining == "" {
-			result := time.Date(yesterday.Year(), yesterday.Month(), yesterday.Day(), 9, 0, 0, 0, refTime.Location())
-			return ParsedTime{Time: result, Original: input}, nil
-		}
-
-		timeResult, err := parseSimpleTime(remaining, yesterday)
-		if err != nil {
-			return ParsedTime{}, err
-		}
-		return ParsedTime{Time: timeResult.Time, Original: input}, nil
+		return dateWithOptionalTime(yesterday, remaining, input)
 	}
 
 	// "next monday/tuesday/etc [time]"
-	weekdays := map[string]time.Weekday{
-		"sunday": time.Sunday, "sun": time.Sunday,
-		"monday": time.Monday, "mon": time.Monday,
-		"tuesday": time.Tuesday, "tue": time.Tuesday, "tues": time.Tuesday,
-		"wednesday": time.Wednesday, "wed": time.Wednesday,
-		"thursday": time.Thursday, "thu": time.Thursday, "thur": time.Thursday, "thurs": time.Thursday,
-		"friday": time.Friday, "fri": time.Friday,
-		"saturday": time.Saturday, "sat": time.Saturday,
-	}
-
 	nextPattern := regexp.MustCompile(`^next\s+(\w+)(?:\s+(.+))?$`)
 	if matches := nextPattern.FindStringSubmatch(input); matches != nil {
 		dayName := matches[1]
 		timeStr := matches[2]
 
-		if targetWeekday, ok := weekdays[dayName]; ok {
+		if targetWeekday, ok := weekdayNames[dayName]; ok {
 			// Find next occurrence of this weekday
 			currentWeekday := refTime.Weekday()
 			daysUntil := int(targetWeekday - currentWeekday)
@@ -144,50 +353,306 @@ func parseRelativeTime(input string, refTime time.Time) (ParsedTime, error) {
 
 			targetDate := refTime.AddDate(0, 0, daysUntil)
 
-			if timeStr == "" {
-				// Default to 9am
-				result := time.Date(targetDate.Year(), targetDate.Month(), targetDate.Day(), 9, 0, 0, 0, refTime.Location())
-				return ParsedTime{Time: result, Original: input}, nil
-			}
+			// Parse the time part, defaulting to 9am
+			return dateWithOptionalTime(targetDate, timeStr, input)
+		}
+	}
+
+	return ParsedTime{}, fmt.Errorf("not a relative time")
+}
+
+// parseBusinessDays handles expressions counted in business days. Weekends are
+// skipped according to the zone of refTime, so Friday/Saturday zones count
+// Sunday as a working day.
+func parseBusinessDays(input string, refTime time.Time) (ParsedTime, error) {
+	var days int
+	var timeStr string
+	var defaultToMorning bool
+
+	inPattern := regexp.MustCompile(`^in\s+(\d+)\s+(?:business|working)\s+days?(?:\s+(.+))?$`)
+	nextPattern := regexp.MustCompile(`^next\s+(?:business|working)\s+day(?:\s+(.+))?$`)
+	agoPattern := regexp.MustCompile(`^(\d+)\s+(?:business|working)\s+days?\s+ago(?:\s+(.+))?$`)
+
+	if matches := inPattern.FindStringSubmatch(input); matches != nil {
+		days, _ = strconv.Atoi(matches[1])
+		timeStr = matches[2]
+	} else if matches := nextPattern.FindStringSubmatch(input); matches != nil {
+		days = 1
+		timeStr = matches[1]
+		defaultToMorning = true
+	} else if matches := agoPattern.FindStringSubmatch(input); matches != nil {
+		days, _ = strconv.Atoi(matches[1])
+		days = -days
+		timeStr = matches[2]
+	} else {
+		return ParsedTime{}, fmt.Errorf("not a business day expression")
+	}
+
+	targetDate := addBusinessDays(refTime, days)
+
+	if timeStr == "" && !defaultToMorning {
+		// "in 3 business days" keeps the current time of day
+		return ParsedTime{Time: targetDate, Original: input}, nil
+	}
+
+	// "next business day" - use 9am as default, like "tomorrow"
+	return dateWithOptionalTime(targetDate, timeStr, input)
+}
+
+// addBusinessDays moves t by n working days, skipping the weekend of t's zone.
+// A negative n counts backwards.
+func addBusinessDays(t time.Time, n int) time.Time {
+	step := 1
+	if n < 0 {
+		step = -1
+		n = -n
+	}
 
-			// Parse the time part
-			timeResult, err := parseSimpleTime(timeStr, targetDate)
+	for n > 0 {
+		t = t.AddDate(0, 0, step)
+		if !timezones.IsWeekend(t.Location(), t.Weekday()) {
+			n--
+		}
+	}
+
+	return t
+}
+
+// monthNames maps full and abbreviated month names to months
+var monthNames = map[string]time.Month{
+	"january": time.January, "jan": time.January,
+	"february": time.February, "feb": time.February,
+	"march": time.March, "mar": time.March,
+	"april": time.April, "apr": time.April,
+	"may":  time.May,
+	"june": time.June, "jun": time.June,
+	"july": time.July, "jul": time.July,
+	"august": time.August, "aug": time.August,
+	"september": time.September, "sep": time.September, "sept": time.September,
+	"october": time.October, "oct": time.October,
+	"november": time.November, "nov": time.November,
+	"december": time.December, "dec": time.December,
+}
+
+// ordinals maps ordinal words to their position; -1 means "last"
+var ordinals = map[string]int{
+	"first": 1, "1st": 1,
+	"second": 2, "2nd": 2,
+	"third": 3, "3rd": 3,
+	"fourth": 4, "4th": 4,
+	"fifth": 5, "5th": 5,
+	"last": -1,
+}
+
+// parseMonthRelative handles expressions anchored to a month or quarter:
+// - Nth weekday: "first monday of next month", "last friday of march 3pm"
+// - Day of month: "the 15th 10am", "the 1st of next month"
+// - Period boundaries: "end of month", "start of next quarter"
+func parseMonthRelative(input string, refTime time.Time) (ParsedTime, error) {
+	loc := refTime.Location()
+
+	nthPattern := regexp.MustCompile(`^(\w+)\s+(\w+)\s+of\s+(this month|next month|last month|[a-z]+)(?:\s+(.+))?$`)
+	if matches := nthPattern.FindStringSubmatch(input); matches != nil {
+		n, okOrdinal := ordinals[matches[1]]
+		weekday, okWeekday := weekdayNames[matches[2]]
+		year, month, okMonth := resolveMonthSpec(matches[3], refTime)
+
+		if okOrdinal && okWeekday && okMonth {
+			date, err := nthWeekdayOfMonth(year, month, weekday, n, loc)
+			latest := date
+			if err != nil {
+				latest = time.Date(year, month, daysIn(year, month, loc), 0, 0, 0, 0, loc)
+			}
+			if passedInNamedMonth(matches[3], latest, refTime) {
+				date, err = nthWeekdayOfMonth(year+1, month, weekday, n, loc)
+			}
 			if err != nil {
 				return ParsedTime{}, err
 			}
-			return ParsedTime{Time: timeResult.Time, Original: input}, nil
+			return dateWithOptionalTime(date, matches[4], input)
 		}
 	}
 
-	return ParsedTime{}, fmt.Errorf("not a relative time")
+	dayPattern := regexp.MustCompile(`^(?:the\s+)?(\d{1,2})(st|nd|rd|th)(?:\s+of\s+(this month|next month|last month|[a-z]+))?(?:\s+(.+))?$`)
+	if matches := dayPattern.FindStringSubmatch(input); matches != nil {
+		day, _ := strconv.Atoi(matches[1])
+		if matches[2] != ordinalSuffix(day) {
+			return ParsedTime{}, fmt.Errorf("invalid ordinal: %s%s", matches[1], matches[2])
+		}
+
+		year, month := refTime.Year(), refTime.Month()
+		if matches[3] != "" {
+			var ok bool
+			if year, month, ok = resolveMonthSpec(matches[3], refTime); !ok {
+				return ParsedTime{}, fmt.Errorf("unknown month: %s", matches[3])
+			}
+			latest := time.Date(year, month, min(day, daysIn(year, month, loc)), 0, 0, 0, 0, loc)
+			if passedInNamedMonth(matches[3], latest, refTime) {
+				year++
+			}
+		}
+
+		if day < 1 || day > daysIn(year, month, loc) {
+			return ParsedTime{}, fmt.Errorf("%s %d has no day %d", month, year, day)
+		}
+
+		date := time.Date(year, month, day, 0, 0, 0, 0, loc)
+		return dateWithOptionalTime(date, matches[4], input)
+	}
+
+	boundaryPattern := regexp.MustCompile(`^(start|beginning|end)\s+of\s+(?:the\s+)?(?:(this|next|last)\s+)?(month|quarter|year)(?:\s+(.+))?$`)
+	if matches := boundaryPattern.FindStringSubmatch(input); matches != nil {
+		shift := 0
+		switch matches[2] {
+		case "next":
+			shift = 1
+		case "last":
+			shift = -1
+		}
+
+		// Work out the first month of the period and its length in months
+		var firstMonth time.Month
+		var length int
+		switch matches[3] {
+		case "month":
+			firstMonth, length = refTime.Month(), 1
+		case "quarter":
+			firstMonth, length = time.Month((int(refTime.Month())-1)/3*3+1), 3
+		case "year":
+			firstMonth, length = time.January, 12
+		}
+
+		start := time.Date(refTime.Year(), firstMonth+time.Month(shift*length), 1, 0, 0, 0, 0, loc)
+
+		date := start
+		if matches[1] == "end" {
+			date = start.AddDate(0, length, -1)
+		}
+		return dateWithOptionalTime(date, matches[4], input)
+	}
+
+	return ParsedTime{}, fmt.Errorf("not a month-relative time")
+}
+
+// resolveMonthSpec turns "this month", "next month", "last month" or a month
+// name into a year and month relative to refTime
+func resolveMonthSpec(spec string, refTime time.Time) (int, time.Month, bool) {
+	var offset int
+	switch spec {
+	case "this month":
+		offset = 0
+	case "next month":
+		offset = 1
+	case "last month":
+		offset = -1
+	default:
+		month, ok := monthNames[spec]
+		if !ok {
+			return 0, 0, false
+		}
+		return refTime.Year(), month, true
+	}
+
+	first := time.Date(refTime.Year(), refTime.Month()+time.Month(offset), 1, 0, 0, 0, 0, refTime.Location())
+	return first.Year(), first.Month(), true
+}
+
+// passedInNamedMonth reports whether date, in a month given by name, is
+// before refTime's date. "last friday of march" asked in April means next
+// year's.
+func passedInNamedMonth(spec string, date, refTime time.Time) bool {
+	_, named := monthNames[spec]
+	return named && calendarDays(refTime, date) < 0
+}
+
+// ordinalSuffix returns the English ordinal suffix of n: "st" for 1 and 21,
+// "th" for 11 to 13
+func ordinalSuffix(n int) string {
+	if n%100 >= 11 && n%100 <= 13 {
+		return "th"
+	}
+	switch n % 10 {
+	case 1:
+		return "st"
+	case 2:
+		return "nd"
+	case 3:
+		return "rd"
+	}
+	return "th"
+}
+
+// nthWeekdayOfMonth returns the nth occurrence of weekday in the month, or the
+// last occurrence when n is -1
+func nthWeekdayOfMonth(year int, month time.Month, weekday time.Weekday, n int, loc *time.Location) (time.Time, error) {
+	if n == -1 {
+		last := time.Date(year, month, daysIn(year, month, loc), 0, 0, 0, 0, loc)
+		back := (int(last.Weekday()) - int(weekday) + 7) % 7
+		return last.AddDate(0, 0, -back), nil
+	}
+
+	first := time.Date(year, month, 1, 0, 0, 0, 0, loc)
+	day := 1 + (int(weekday)-int(first.Weekday())+7)%7 + 7*(n-1)
+	if day > daysIn(year, month, loc) {
+		return time.Time{}, fmt.Errorf("%s %d has no %d %s", month, year, n, weekday)
+	}
+	return time.Date(year, month, day, 0, 0, 0, 0, loc), nil
+}
+
+// daysIn returns the number of days in the given month
+func daysIn(year int, month time.Month, loc *time.Location) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, loc).Day()
+}
+
+// dateWithOptionalTime applies timeStr to date, defaulting to 9am when no
+// time was given
+func dateWithOptionalTime(date time.Time, timeStr string, input string) (ParsedTime, error) {
+	if timeStr == "" {
+		result := time.Date(date.Year(), date.Month(), date.Day(), 9, 0, 0, 0, date.Location())
+		return ParsedTime{Time: result, Original: input, Precision: PrecisionDate, DefaultedTime: true}, nil
+	}
+
+	timeResult, err := parseSimpleTime(timeStr, date)
+	if err != nil {
+		return ParsedTime{}, err
+	}
+	return ParsedTime{Time: timeResult.Time, Original: input, Precision: timeResult.Precision, Guess: timeResult.Guess}, nil
 }
 
 // parseDateWithTime handles explicit dates with times
 func parseDateWithTime(input string, refTime time.Time) (ParsedTime, error) {
+	// ISO week dates: 2026-W12-1 10am, week 12 monday 3pm, W12 fri
+	if result, err := parseWeekDate(input, refTime); err == nil {
+		return result, nil
+	}
+
 	// Try various date formats
 	dateTimeFormats := []struct {
 		pattern string
 		layout  string
 	}{
-		// ISO format: 2026-01-20 3pm, 2026-01-20 15:04
-		{`^(\d{4}-\d{2}-\d{2})\s+(.+)$`, "2006-01-02"},
-		// US format: Jan 20 3pm, January 20 3pm
-		{`^(jan|feb|mar|apr|may|jun|jul|aug|sep|oct|nov|dec)[a-z]*\s+(\d{1,2})\s+(.+)$`, "Jan 2"},
-		// Numeric: 1/20 3pm, 01/20 3pm
-		{`^(\d{1,2}/\d{1,2})\s+(.+)$`, "1/2"},
+		// ISO format: 2026-01-20 3pm, 2026-01-20 15:04, 2026-01-20
+		{`^(\d{4}-\d{2}-\d{2})(?:\s+(.+))?$`, "2006-01-02"},
+		// US format: Jan 20 3pm, January 20 3pm, Jan 20
+		{`^((?:jan|feb|mar|apr|may|jun|jul|aug|sep|oct|nov|dec)[a-z]*)\s+(\d{1,2})(?:\s+(.+))?$`, "Jan 2"},
+		// Numeric: 1/20 3pm, 01/20 3pm, 1/20
+		{`^(\d{1,2}/\d{1,2})(?:\s+(.+))?$`, "1/2"},
 	}
 
 	for _, format := range dateTimeFormats {
 		pattern := regexp.MustCompile(format.pattern)
 		if matches := pattern.FindStringSubmatch(input); matches != nil {
-			var dateStr, timeStr string
+			var dateStr, timeStr, guess string
 
 			if len(matches) == 3 {
 				dateStr = matches[1]
 				timeStr = matches[2]
 			} else if len(matches) == 4 {
-				// For month name format
-				dateStr = matches[1] + " " + matches[2]
+				// For month name format, read by its first three letters
+				if _, ok := monthNames[matches[1]]; !ok {
+					guess = fmt.Sprintf("'%s' read as %s", matches[1], monthNames[matches[1][:3]])
+				}
+				dateStr = matches[1][:3] + " " + matches[2]
 				timeStr = matches[3]
 			} else {
 				continue
@@ -212,20 +677,86 @@ func parseDateWithTime(input string, refTime time.Time) (ParsedTime, error) {
 			if err != nil {
 				continue
 			}
+			baseDate = time.Date(baseDate.Year(), baseDate.Month(), baseDate.Day(), 0, 0, 0, 0, refTime.Location())
 
-			// Parse the time part
-			timeResult, err := parseSimpleTime(timeStr, baseDate)
+			// Parse the time part, defaulting to 9am for a bare date
+			result, err := dateWithOptionalTime(baseDate, timeStr, input)
 			if err != nil {
 				continue
 			}
+			if guess != "" {
+				result.Guess = guess
+			}
 
-			return ParsedTime{Time: timeResult.Time, Original: input}, nil
+			return result, nil
 		}
 	}
 
 	return ParsedTime{}, fmt.Errorf("not a date with time")
 }
 
+// parseWeekDate handles ISO 8601 week dates. The day defaults to Monday and
+// the year to the current ISO year.
+func parseWeekDate(input string, refTime time.Time) (ParsedTime, error) {
+	var year, week int
+	weekday := time.Monday
+	var timeStr string
+
+	isoPattern := regexp.MustCompile(`^(\d{4})-?w(\d{2})(?:-?([1-7]))?(?:\s+(.+))?$`)
+	namedPattern := regexp.MustCompile(`^(?:week\s+|w)(\d{1,2})(?:\s+([a-z]+))?(?:\s+(.+))?$`)
+
+	if matches := isoPattern.FindStringSubmatch(input); matches != nil {
+		year, _ = strconv.Atoi(matches[1])
+		week, _ = strconv.Atoi(matches[2])
+		if matches[3] != "" {
+			day, _ := strconv.Atoi(matches[3])
+			weekday = time.Weekday(day % 7)
+		}
+		timeStr = matches[4]
+	} else if matches := namedPattern.FindStringSubmatch(input); matches != nil {
+		year, _ = refTime.ISOWeek()
+		week, _ = strconv.Atoi(matches[1])
+		timeStr = matches[3]
+		if day, ok := weekdayNames[matches[2]]; ok {
+			weekday = day
+		} else if matches[2] != "" {
+			// Not a day name, so it starts the time: "week 12 3pm"
+			timeStr = strings.TrimSpace(matches[2] + " " + matches[3])
+		}
+	} else {
+		return ParsedTime{}, fmt.Errorf("not a week date")
+	}
+
+	if week < 1 || week > isoWeeksIn(year) {
+		return ParsedTime{}, fmt.Errorf("%d has no ISO week %d", year, week)
+	}
+
+	// Monday is day 1 of the ISO week, Sunday day 7
+	date := isoWeekStart(year, week, refTime.Location()).AddDate(0, 0, (int(weekday)+6)%7)
+	return dateWithOptionalTime(date, timeStr, input)
+}
+
+// isoWeekStart returns the Monday of the given ISO week. Week 1 is the week
+// containing January 4th.
+func isoWeekStart(year, week int, loc *time.Location) time.Time {
+	jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, loc)
+	monday := jan4.AddDate(0, 0, -((int(jan4.Weekday()) + 6) % 7))
+	return monday.AddDate(0, 0, 7*(week-1))
+}
+
+// isoWeeksIn returns the number of ISO weeks in year: 52 or 53
+func isoWeeksIn(year int) int {
+	// December 28th always falls in the last week of its ISO year
+	_, week := time.Date(year, time.December, 28, 0, 0, 0, 0, time.UTC).ISOWeek()
+	return week
+}
+
+// isoWeekDate formats t as an ISO 8601 week date: "2026-W12-1"
+func isoWeekDate(t time.Time) string {
+	year, week := t.ISOWeek()
+	return fmt.Sprintf("%d-W%02d-%d", year, week, (int(t.Weekday())+6)%7+1)
+}
+
 // parseSimpleTime handles basic time formats (original parseTime logic)
 func parseSimpleTime(input string, baseDate time.Time) (ParsedTime, error) {
 	input = strings.ToLower(strings.TrimSpace(input))
@@ -234,10 +765,10 @@ func parseSimpleTime(input string, baseDate time.Time) (ParsedTime, error) {
 	switch input {
 	case "noon":
 		result := time.Date(baseDate.Year(), baseDate.Month(), baseDate.Day(), 12, 0, 0, 0, baseDate.Location())
-		return ParsedTime{Time: result, Original: input}, nil
+		return ParsedTime{Time: result, Original: input, Precision: PrecisionHour}, nil
 	case "midnight":
 		result := time.Date(baseDate.Year(), baseDate.Month(), baseDate.Day(), 0, 0, 0, 0, baseDate.Location())
-		return ParsedTime{Time: result, Original: input}, nil
+		return ParsedTime{Time: result, Original: input, Precision: PrecisionHour}, nil
 	}
 
 	// Try parsing with various time formats
@@ -260,7 +791,46 @@ func parseSimpleTime(input string, baseDate time.Time) (ParsedTime, error) {
 				parsed.Hour(), parsed.Minute(), parsed.Second(),
 				0, baseDate.Location(),
 			)
-			return ParsedTime{Time: result, Original: input}, nil
+			precision := PrecisionHour
+			if strings.Contains(format, ":04") {
+				precision = PrecisionMinute
+			}
+			guess := ""
+			if format == "15" || format == "3" {
+				guess = loneHourGuess(input, parsed.Hour())
+			}
+			return ParsedTime{Time: result, Original: input, Precision: precision, Guess: guess}, nil
+		}
+	}
+
+	// Spoken times: "quarter past 3", "half past 9 pm", "10 to 6", "3 o'clock"
+	spokenPattern := regexp.MustCompile(`^(\d{1,2}|quarter|half)\s+(past|after|to|before|til|till)\s+(\d{1,2}(?:\s*(?:am|pm))?)$`)
+	if matches := spokenPattern.FindStringSubmatch(input); matches != nil {
+		var minutes int
+		switch matches[1] {
+		case "quarter":
+			minutes = 15
+		case "half":
+			minutes = 30
+		default:
+			minutes, _ = strconv.Atoi(matches[1])
+		}
+
+		hourResult, err := parseSimpleTime(matches[3], baseDate)
+		if err != nil || minutes >= 60 {
+			return ParsedTime{}, fmt.Errorf("invalid time format")
+		}
+
+		offset := time.Duration(minutes) * time.Minute
+		if matches[2] != "past" && matches[2] != "after" {
+			offset = -offset
+		}
+		result := hourResult.Time.Add(offset)
+		return ParsedTime{Time: result, Original: input, Precision: PrecisionMinute, Guess: hourResult.Guess}, nil
+	}
+	for _, suffix := range []string{" o'clock", " oclock"} {
+		if hour, ok := strings.CutSuffix(input, suffix); ok {
+			return parseSimpleTime(hour, baseDate)
 		}
 	}
 
@@ -285,9 +855,28 @@ func parseSimpleTime(input string, baseDate time.Time) (ParsedTime, error) {
 				baseDate.Year(), baseDate.Month(), baseDate.Day(),
 				hour, minute, 0, 0, baseDate.Location(),
 			)
-			return ParsedTime{Time: result, Original: input}, nil
+			precision := PrecisionHour
+			guess := ""
+			if matches[2] != "" {
+				precision = PrecisionMinute
+			} else if matches[3] == "" {
+				guess = loneHourGuess(matches[1], hour)
+			}
+			return ParsedTime{Time: result, Original: input, Precision: precision, Guess: guess}, nil
 		}
 	}
 
 	return ParsedTime{}, fmt.Errorf("invalid time format")
 }
+
+// loneHourGuess explains how a lone number was read as an hour and how to
+// write it unambiguously
+func loneHourGuess(number string, hour int) string {
+	if hour == 0 {
+		return fmt.Sprintf("lone number '%s' read as an hour; write 12am or 00:00", number)
+	}
+	if hour > 12 {
+		return fmt.Sprintf("lone number '%s' read as an hour; write %dpm or %s:00", number, hour-12, number)
+	}
+	return fmt.Sprintf("lone number '%s' read as an hour; write %sam or %spm", number, number, number)
+}
@@ -262,6 +262,50 @@ func TestParseTimeWithContext(t *testing.T) {
 			wantYear:   2026,
 		},
 
+		// Time before date
+		{
+			name:       "9am tomorrow",
+			input:      "9am tomorrow",
+			refTime:    refTime,
+			wantHour:   9,
+			wantMinute: 0,
+			wantDay:    17,
+			wantMonth:  time.January,
+			wantYear:   2026,
+		},
+		{
+			name:       "3pm next monday",
+			input:      "3pm next monday",
+			refTime:    refTime,
+			wantHour:   15,
+			wantMinute: 0,
+			wantDay:    19,
+			wantMonth:  time.January,
+			wantYear:   2026,
+		},
+
+		// Date only
+		{
+			name:       "2027-01-01",
+			input:      "2027-01-01",
+			refTime:    refTime,
+			wantHour:   9,
+			wantMinute: 0,
+			wantDay:    1,
+			wantMonth:  time.January,
+			wantYear:   2027,
+		},
+		{
+			name:       "feb 14",
+			input:      "feb 14",
+			refTime:    refTime,
+			wantHour:   9,
+			wantMinute: 0,
+			wantDay:    14,
+			wantMonth:  time.February,
+			wantYear:   2026,
+		},
+
 		// Error cases
 		{
 			name:        "empty string",
@@ -522,3 +566,664 @@ func TestParseSimpleTime(t *testing.T) {
 		})
 	}
 }
+
+func TestParseBusinessDays(t *testing.T) {
+	// Friday, January 16, 2026 at 2:30 PM
+	refTime := time.Date(2026, 1, 16, 14, 30, 0, 0, time.UTC)
+
+	riyadh, err := time.LoadLocation("Asia/Riyadh")
+	if err != nil {
+		t.Skipf("tzdata not available: %v", err)
+	}
+	riyadhRef := time.Date(2026, 1, 15, 14, 30, 0, 0, riyadh) // Thursday
+
+	tests := []struct {
+		name      string
+		input     string
+		refTime   time.Time
+		wantDay   int
+		wantHour  int
+		wantError bool
+	}{
+		{
+			name:     "in 3 business days 5pm",
+			input:    "in 3 business days 5pm",
+			refTime:  refTime,
+			wantDay:  21, // Mon, Tue, Wed
+			wantHour: 17,
+		},
+		{
+			name:     "in 1 working day keeps time",
+			input:    "in 1 working day",
+			refTime:  refTime,
+			wantDay:  19,
+			wantHour: 14,
+		},
+		{
+			name:     "next business day 9am",
+			input:    "next business day 9am",
+			refTime:  refTime,
+			wantDay:  19,
+			wantHour: 9,
+		},
+		{
+			name:     "next business day default",
+			input:    "next business day",
+			refTime:  refTime,
+			wantDay:  19,
+			wantHour: 9,
+		},
+		{
+			name:     "2 working days ago",
+			input:    "2 working days ago",
+			refTime:  refTime,
+			wantDay:  14,
+			wantHour: 14,
+		},
+		{
+			name:     "friday/saturday weekend",
+			input:    "next business day",
+			refTime:  riyadhRef,
+			wantDay:  18, // Sunday
+			wantHour: 9,
+		},
+		{
+			name:     "friday/saturday weekend backwards",
+			input:    "3 business days ago 10am",                   // Sun 18, Thu 15, Wed 14
+			refTime:  time.Date(2026, 1, 19, 14, 30, 0, 0, riyadh), // Monday
+			wantDay:  14,
+			wantHour: 10,
+		},
+		{
+			name:      "not business days",
+			input:     "in 3 days",
+			refTime:   refTime,
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parseBusinessDays(tt.input, tt.refTime)
+
+			if tt.wantError {
+				if err == nil {
+					t.Errorf("expected error but got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if result.Time.Day() != tt.wantDay {
+				t.Errorf("day = %d, want %d", result.Time.Day(), tt.wantDay)
+			}
+			if result.Time.Hour() != tt.wantHour {
+				t.Errorf("hour = %d, want %d", result.Time.Hour(), tt.wantHour)
+			}
+		})
+	}
+}
+
+func TestParseMonthRelative(t *testing.T) {
+	// Friday, January 16, 2026 at 2:30 PM
+	refTime := time.Date(2026, 1, 16, 14, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		input     string
+		wantYear  int
+		wantMonth time.Month
+		wantDay   int
+		wantHour  int
+		wantError bool
+	}{
+		{
+			name:      "first monday of next month",
+			input:     "first monday of next month",
+			wantYear:  2026,
+			wantMonth: time.February,
+			wantDay:   2,
+			wantHour:  9,
+		},
+		{
+			name:      "last friday of march 3pm",
+			input:     "last friday of march 3pm",
+			wantYear:  2026,
+			wantMonth: time.March,
+			wantDay:   27,
+			wantHour:  15,
+		},
+		{
+			name:      "third wednesday of this month",
+			input:     "3rd wed of this month",
+			wantYear:  2026,
+			wantMonth: time.January,
+			wantDay:   21,
+			wantHour:  9,
+		},
+		{
+			name:      "the 15th 10am",
+			input:     "the 15th 10am",
+			wantYear:  2026,
+			wantMonth: time.January,
+			wantDay:   15,
+			wantHour:  10,
+		},
+		{
+			name:      "the 1st of next month",
+			input:     "the 1st of next month",
+			wantYear:  2026,
+			wantMonth: time.February,
+			wantDay:   1,
+			wantHour:  9,
+		},
+		{
+			name:      "end of month",
+			input:     "end of month",
+			wantYear:  2026,
+			wantMonth: time.January,
+			wantDay:   31,
+			wantHour:  9,
+		},
+		{
+			name:      "end of next month 5pm",
+			input:     "end of next month 5pm",
+			wantYear:  2026,
+			wantMonth: time.February,
+			wantDay:   28,
+			wantHour:  17,
+		},
+		{
+			name:      "start of next quarter",
+			input:     "start of next quarter",
+			wantYear:  2026,
+			wantMonth: time.April,
+			wantDay:   1,
+			wantHour:  9,
+		},
+		{
+			name:      "end of last quarter",
+			input:     "end of last quarter",
+			wantYear:  2025,
+			wantMonth: time.December,
+			wantDay:   31,
+			wantHour:  9,
+		},
+		{
+			name:      "named month already past",
+			input:     "first friday of january",
+			wantYear:  2027,
+			wantMonth: time.January,
+			wantDay:   1,
+			wantHour:  9,
+		},
+		{
+			name:      "named month earlier in the year",
+			input:     "the 22nd of december 8am",
+			wantYear:  2026,
+			wantMonth: time.December,
+			wantDay:   22,
+			wantHour:  8,
+		},
+		{
+			name:      "day already past in a named month",
+			input:     "the 2nd of january",
+			wantYear:  2027,
+			wantMonth: time.January,
+			wantDay:   2,
+			wantHour:  9,
+		},
+		{
+			name:      "day later in the named month",
+			input:     "the 16th of jan",
+			wantYear:  2026,
+			wantMonth: time.January,
+			wantDay:   16,
+			wantHour:  9,
+		},
+		{
+			name:      "the 11th",
+			input:     "the 11th 10am",
+			wantYear:  2026,
+			wantMonth: time.January,
+			wantDay:   11,
+			wantHour:  10,
+		},
+		{
+			name:      "mismatched suffix",
+			input:     "the 15st",
+			wantError: true,
+		},
+		{
+			name:      "mismatched suffix after 1",
+			input:     "2th of march",
+			wantError: true,
+		},
+		{
+			name:      "fifth monday that does not exist",
+			input:     "fifth monday of february",
+			wantError: true,
+		},
+		{
+			name:      "day out of range",
+			input:     "the 31st of next month",
+			wantError: true,
+		},
+		{
+			name:      "not month-relative",
+			input:     "tomorrow 3pm",
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parseMonthRelative(tt.input, refTime)
+
+			if tt.wantError {
+				if err == nil {
+					t.Errorf("expected error but got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if result.Time.Year() != tt.wantYear {
+				t.Errorf("year = %d, want %d", result.Time.Year(), tt.wantYear)
+			}
+			if result.Time.Month() != tt.wantMonth {
+				t.Errorf("month = %v, want %v", result.Time.Month(), tt.wantMonth)
+			}
+			if result.Time.Day() != tt.wantDay {
+				t.Errorf("day = %d, want %d", result.Time.Day(), tt.wantDay)
+			}
+			if result.Time.Hour() != tt.wantHour {
+				t.Errorf("hour = %d, want %d", result.Time.Hour(), tt.wantHour)
+			}
+		})
+	}
+}
+
+func TestParseSpokenTime(t *testing.T) {
+	refTime := time.Date(2026, 1, 16, 14, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name            string
+		input           string
+		wantDay         int
+		wantHour        int
+		wantMinute      int
+		wantApproximate bool
+		wantError       bool
+	}{
+		{
+			name:       "quarter past 3",
+			input:      "quarter past 3",
+			wantDay:    16,
+			wantHour:   3,
+			wantMinute: 15,
+		},
+		{
+			name:       "half past nine pm",
+			input:      "half past nine pm",
+			wantDay:    16,
+			wantHour:   21,
+			wantMinute: 30,
+		},
+		{
+			name:       "ten to six",
+			input:      "ten to six",
+			wantDay:    16,
+			wantHour:   5,
+			wantMinute: 50,
+		},
+		{
+			name:       "twenty-five past 4pm",
+			input:      "twenty-five past 4pm",
+			wantDay:    16,
+			wantHour:   16,
+			wantMinute: 25,
+		},
+		{
+			name:       "in two hours",
+			input:      "in two hours",
+			wantDay:    16,
+			wantHour:   16,
+			wantMinute: 30,
+		},
+		{
+			name:       "three pm",
+			input:      "three pm",
+			wantDay:    16,
+			wantHour:   15,
+			wantMinute: 0,
+		},
+		{
+			name:       "tomorrow five pm",
+			input:      "tomorrow five pm",
+			wantDay:    17,
+			wantHour:   17,
+			wantMinute: 0,
+		},
+		{
+			name:       "seven o'clock",
+			input:      "seven o'clock",
+			wantDay:    16,
+			wantHour:   7,
+			wantMinute: 0,
+		},
+		{
+			name:            "noonish",
+			input:           "noonish",
+			wantDay:         16,
+			wantHour:        12,
+			wantMinute:      0,
+			wantApproximate: true,
+		},
+		{
+			name:            "around 3",
+			input:           "around 3",
+			wantDay:         16,
+			wantHour:        3,
+			wantMinute:      0,
+			wantApproximate: true,
+		},
+		{
+			name:            "tomorrow ~4pm",
+			input:           "tomorrow ~4pm",
+			wantDay:         17,
+			wantHour:        16,
+			wantMinute:      0,
+			wantApproximate: true,
+		},
+		{
+			name:      "sixty past three",
+			input:     "sixty past three",
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parseTimeWithContext(tt.input, refTime)
+
+			if tt.wantError {
+				if err == nil {
+					t.Errorf("expected error but got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if result.Time.Day() != tt.wantDay {
+				t.Errorf("day = %d, want %d", result.Time.Day(), tt.wantDay)
+			}
+			if result.Time.Hour() != tt.wantHour {
+				t.Errorf("hour = %d, want %d", result.Time.Hour(), tt.wantHour)
+			}
+			if result.Time.Minute() != tt.wantMinute {
+				t.Errorf("minute = %d, want %d", result.Time.Minute(), tt.wantMinute)
+			}
+			if result.Approximate != tt.wantApproximate {
+				t.Errorf("approximate = %v, want %v", result.Approximate, tt.wantApproximate)
+			}
+		})
+	}
+}
+
+func TestParseTimeRollover(t *testing.T) {
+	// Friday, January 16, 2026 at 2:30 PM
+	refTime := time.Date(2026, 1, 16, 14, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		input          string
+		opts           parseOptions
+		wantDay        int
+		wantHour       int
+		wantRolledDays int
+	}{
+		{
+			name:     "past time stays today by default",
+			input:    "10am",
+			wantDay:  16,
+			wantHour: 10,
+		},
+		{
+			name:           "past time rolls over",
+			input:          "10am",
+			opts:           parseOptions{Rollover: true},
+			wantDay:        17,
+			wantHour:       10,
+			wantRolledDays: 1,
+		},
+		{
+			name:     "future time does not roll over",
+			input:    "3pm",
+			opts:     parseOptions{Rollover: true},
+			wantDay:  16,
+			wantHour: 15,
+		},
+		{
+			name:     "dated time does not roll over",
+			input:    "yesterday 10am",
+			opts:     parseOptions{Rollover: true},
+			wantDay:  15,
+			wantHour: 10,
+		},
+		{
+			name:           "next keyword",
+			input:          "next 10am",
+			wantDay:        17,
+			wantHour:       10,
+			wantRolledDays: 1,
+		},
+		{
+			name:           "last keyword",
+			input:          "last 3pm",
+			wantDay:        15,
+			wantHour:       15,
+			wantRolledDays: -1,
+		},
+		{
+			name:     "last keyword overrides rollover",
+			input:    "last noon",
+			opts:     parseOptions{Rollover: true},
+			wantDay:  16,
+			wantHour: 12,
+		},
+		{
+			name:     "next weekday is unaffected",
+			input:    "next monday 3pm",
+			wantDay:  19,
+			wantHour: 15,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parseTimeWithOptions(tt.input, refTime, tt.opts)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if result.Time.Day() != tt.wantDay {
+				t.Errorf("day = %d, want %d", result.Time.Day(), tt.wantDay)
+			}
+			if result.Time.Hour() != tt.wantHour {
+				t.Errorf("hour = %d, want %d", result.Time.Hour(), tt.wantHour)
+			}
+			if result.RolledDays != tt.wantRolledDays {
+				t.Errorf("rolled days = %d, want %d", result.RolledDays, tt.wantRolledDays)
+			}
+		})
+	}
+}
+
+func TestParsePrecision(t *testing.T) {
+	refTime := time.Date(2026, 1, 16, 14, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		input             string
+		wantPrecision     Precision
+		wantDefaultedDate bool
+		wantDefaultedTime bool
+	}{
+		{input: "now", wantPrecision: PrecisionSecond},
+		{input: "in 2 hours", wantPrecision: PrecisionSecond},
+		{input: "3pm", wantPrecision: PrecisionHour, wantDefaultedDate: true},
+		{input: "3:30pm", wantPrecision: PrecisionMinute, wantDefaultedDate: true},
+		{input: "15:04", wantPrecision: PrecisionMinute, wantDefaultedDate: true},
+		{input: "noon", wantPrecision: PrecisionHour, wantDefaultedDate: true},
+		{input: "quarter past 3", wantPrecision: PrecisionMinute, wantDefaultedDate: true},
+		{input: "tomorrow", wantPrecision: PrecisionDate, wantDefaultedTime: true},
+		{input: "tomorrow 9am", wantPrecision: PrecisionHour},
+		{input: "next monday 10:30am", wantPrecision: PrecisionMinute},
+		{input: "jan 20", wantPrecision: PrecisionDate, wantDefaultedTime: true},
+		{input: "2026-01-20 3pm", wantPrecision: PrecisionHour},
+		{input: "end of month", wantPrecision: PrecisionDate, wantDefaultedTime: true},
+		{input: "in 3 business days", wantPrecision: PrecisionSecond},
+		{input: "2 working days ago", wantPrecision: PrecisionSecond},
+		{input: "next business day 9:15am", wantPrecision: PrecisionMinute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result, err := parseTimeWithContext(tt.input, refTime)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if result.Precision != tt.wantPrecision {
+				t.Errorf("precision = %v, want %v", result.Precision, tt.wantPrecision)
+			}
+			if result.DefaultedDate != tt.wantDefaultedDate {
+				t.Errorf("defaulted date = %v, want %v", result.DefaultedDate, tt.wantDefaultedDate)
+			}
+			if result.DefaultedTime != tt.wantDefaultedTime {
+				t.Errorf("defaulted time = %v, want %v", result.DefaultedTime, tt.wantDefaultedTime)
+			}
+			if result.AllDay() != (tt.wantPrecision == PrecisionDate) {
+				t.Errorf("all day = %v, want %v", result.AllDay(), tt.wantPrecision == PrecisionDate)
+			}
+		})
+	}
+}
+
+func TestParseTimeStrict(t *testing.T) {
+	refTime := time.Date(2026, 1, 16, 14, 30, 0, 0, time.UTC)
+	strict := parseOptions{Strict: true}
+
+	tests := []struct {
+		input   string
+		wantErr bool
+	}{
+		{input: "3pm"},
+		{input: "15:00"},
+		{input: "3:30pm"},
+		{input: "noon"},
+		{input: "tomorrow 9am"},
+		{input: "jan 20 3pm"},
+		{input: "january 20"},
+		{input: "sept 5"},
+		{input: "quarter past 3pm"},
+		{input: "10 to 6 am"},
+		{input: "3pm o'clock"},
+		{input: "in 2 hours"},
+		{input: "the 15th 10am"},
+		{input: "3", wantErr: true},
+		{input: "15", wantErr: true},
+		{input: "0", wantErr: true},
+		{input: "tomorrow 3", wantErr: true},
+		{input: "around 3", wantErr: true},
+		{input: "quarter past 3", wantErr: true},
+		{input: "ten to six", wantErr: true},
+		{input: "half past 15", wantErr: true},
+		{input: "3 o'clock", wantErr: true},
+		{input: "next 3", wantErr: true},
+		{input: "marching 20", wantErr: true},
+		{input: "decoy 5 3pm", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			// Every input parses leniently; strict mode only rejects guesses
+			if _, err := parseTimeWithOptions(tt.input, refTime, parseOptions{}); err != nil {
+				t.Fatalf("lenient parse failed: %v", err)
+			}
+
+			_, err := parseTimeWithOptions(tt.input, refTime, strict)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("strict error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseWeekDate(t *testing.T) {
+	refTime := time.Date(2026, 1, 16, 14, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		input   string
+		want    time.Time
+		wantErr bool
+	}{
+		{input: "2026-W12-1 10am", want: time.Date(2026, 3, 16, 10, 0, 0, 0, time.UTC)},
+		{input: "2026W127 3pm", want: time.Date(2026, 3, 22, 15, 0, 0, 0, time.UTC)},
+		{input: "2026-w12", want: time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC)},
+		{input: "week 12 monday 3pm", want: time.Date(2026, 3, 16, 15, 0, 0, 0, time.UTC)},
+		{input: "week 12 3pm", want: time.Date(2026, 3, 16, 15, 0, 0, 0, time.UTC)},
+		{input: "w12 fri", want: time.Date(2026, 3, 20, 9, 0, 0, 0, time.UTC)},
+		{input: "3pm w12 fri", want: time.Date(2026, 3, 20, 15, 0, 0, 0, time.UTC)},
+		// Week 1 of 2026 starts in December 2025
+		{input: "2026-W01-1", want: time.Date(2025, 12, 29, 9, 0, 0, 0, time.UTC)},
+		{input: "w53 thu", want: time.Date(2026, 12, 31, 9, 0, 0, 0, time.UTC)},
+		{input: "2027-W53-1", wantErr: true},
+		{input: "week 0 monday", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result, err := parseTimeWithContext(tt.input, refTime)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error, got %v", result.Time)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !result.Time.Equal(tt.want) {
+				t.Errorf("got %v, want %v", result.Time, tt.want)
+			}
+		})
+	}
+}
+
+func TestISOWeekDate(t *testing.T) {
+	tests := []struct {
+		date time.Time
+		want string
+	}{
+		{time.Date(2026, 3, 16, 0, 0, 0, 0, time.UTC), "2026-W12-1"},
+		{time.Date(2026, 3, 22, 0, 0, 0, 0, time.UTC), "2026-W12-7"},
+		{time.Date(2025, 12, 29, 0, 0, 0, 0, time.UTC), "2026-W01-1"},
+		{time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC), "2026-W53-5"},
+	}
+
+	for _, tt := range tests {
+		if got := isoWeekDate(tt.date); got != tt.want {
+			t.Errorf("isoWeekDate(%v) = %s, want %s", tt.date, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,702 @@
+package main
+
+import (
+	"aeon/timezones"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// conversionQuery is a Convert view query broken into its time expression
+// and resolved source and target zones
+type conversionQuery struct {
+	TimeExpr   string
+	SourceZone string
+	SourceLoc  *time.Location
+	// Targets lists the target zones: "to Berlin, Tokyo, Sydney"
+	Targets []conversionTarget
+	// AllTargets is set for "to all", meaning every zone on the Clock view
+	AllTargets bool
+	// Offsets are applied in order to the source time: "3pm NYC + 90m"
+	Offsets []timeOffset
+}
+
+// String renders the query in the form the parser reads back:
+// "3pm NYC + 90m to Berlin, Tokyo"
+func (q conversionQuery) String() string {
+	var parts []string
+	for _, part := range []string{q.TimeExpr, q.SourceZone} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	for _, offset := range q.Offsets {
+		parts = append(parts, offset.Text)
+	}
+
+	targets := make([]string, len(q.Targets))
+	for i, t := range q.Targets {
+		targets[i] = t.Zone
+	}
+	if q.AllTargets {
+		targets = []string{"all"}
+	}
+
+	return strings.Join(parts, " ") + " to " + strings.Join(targets, ", ")
+}
+
+// swapped returns the query with its source and single target exchanged:
+// "3pm NYC to Berlin" becomes "3pm Berlin to NYC"
+func (q conversionQuery) swapped() (conversionQuery, bool) {
+	if q.AllTargets || len(q.Targets) != 1 {
+		return conversionQuery{}, false
+	}
+
+	target := q.Targets[0]
+	q.Targets = []conversionTarget{{Zone: q.SourceZone, Loc: q.SourceLoc}}
+	q.SourceZone, q.SourceLoc = target.Zone, target.Loc
+	return q, true
+}
+
+// conversionTarget is one target zone of a query
+type conversionTarget struct {
+	Zone string
+	Loc  *time.Location
+}
+
+// timeOffset is a "+ 90m" or "- 2 business days" term in a query
+type timeOffset struct {
+	Text         string
+	Sign         int
+	Duration     time.Duration
+	Days         int
+	BusinessDays int
+}
+
+// apply adds the offset to t. Days and business days keep the wall clock;
+// durations count elapsed time.
+func (o timeOffset) apply(t time.Time) time.Time {
+	return o.applyWall(t).Instants[0]
+}
+
+// applyWall adds the offset to t like apply, placing the wall time reached
+// by days and business days in t's zone so DST gaps and overlaps are
+// detected
+func (o timeOffset) applyWall(t time.Time) wallClock {
+	t = t.Add(time.Duration(o.Sign) * o.Duration)
+	if o.Days == 0 && o.BusinessDays == 0 {
+		return wallClock{Wall: t, Instants: []time.Time{t}}
+	}
+
+	wall := floatingTime(t).AddDate(0, 0, o.Sign*o.Days)
+	wall = addBusinessDays(wall, o.Sign*o.BusinessDays)
+	return resolveWallClock(wall, t.Location())
+}
+
+// resolvedTime is a query's time expression placed in its zone
+type resolvedTime struct {
+	Parsed ParsedTime
+	// Wall describes how the parsed wall time, or the one reached by the
+	// last day-based offset, maps onto instants. It is empty when neither
+	// named a wall time, as with "now".
+	Wall wallClock
+	// WallOffsets are the offsets applied after Wall
+	WallOffsets []timeOffset
+	// Base is the resolved instant before offsets, Time the one after
+	Base time.Time
+	Time time.Time
+}
+
+// resolveTimeExpr parses timeExpr against the wall clock of now in loc, then
+// places the result in loc so DST gaps and overlaps are detected, and
+// finally applies arithmetic such as "+ 90m" or "+ 2 business days"
+func resolveTimeExpr(timeExpr string, loc *time.Location, offsets []timeOffset, now time.Time, opts parseOptions) (resolvedTime, error) {
+	now = now.In(loc)
+	floatingNow := floatingTime(now)
+	parsed, err := parseTimeWithOptions(timeExpr, floatingNow, opts)
+	if err != nil {
+		return resolvedTime{}, fmt.Errorf("Invalid time expression '%s': %v", timeExpr, err)
+	}
+
+	resolved := resolvedTime{Parsed: parsed}
+	if parsed.Exact {
+		resolved.Base = now.Add(parsed.Time.Sub(floatingNow))
+	} else {
+		resolved.Wall = resolveWallClock(parsed.Time, loc)
+		resolved.Base = resolved.Wall.Instants[0]
+	}
+
+	resolved.Time = resolved.Base
+	resolved.WallOffsets = offsets
+	for i, offset := range offsets {
+		wall := offset.applyWall(resolved.Time)
+		if offset.Days != 0 || offset.BusinessDays != 0 {
+			resolved.Wall, resolved.WallOffsets = wall, offsets[i+1:]
+		}
+		resolved.Time = wall.Instants[0]
+	}
+
+	return resolved, nil
+}
+
+// localZoneName is the display name used for the user's own zone
+const localZoneName = "Local"
+
+// localZoneWords are phrases that refer to the user's own zone
+var localZoneWords = map[string]bool{
+	"here":        true,
+	"local":       true,
+	"locally":     true,
+	"local time":  true,
+	"my time":     true,
+	"my timezone": true,
+	"my zone":     true,
+}
+
+// separatorWords are the words and arrows that separate the source side of a
+// query from its target zone
+var separatorWords = map[string]bool{
+	"to": true,
+	"in": true,
+	"as": true,
+	"->": true,
+	"→":  true,
+}
+
+// questionPrefixes are leading phrases that ask for the current time in the
+// zone that follows
+var questionPrefixes = [][]string{
+	{"what", "time", "is", "it", "in"},
+	{"what's", "the", "time", "in"},
+	{"what", "is", "the", "time", "in"},
+	{"whats", "the", "time", "in"},
+	{"time", "in"},
+}
+
+// token is a single word or arrow in a query
+type token struct {
+	Text string
+	// Separator is set when the token can separate source and target.
+	// Separator words may still belong to a zone span ("Barrow in Furness")
+	// or a time expression ("in 2 hours").
+	Separator bool
+}
+
+// tokenizeQuery splits a query into word and arrow tokens. Arrows are split
+// out even when written without spaces ("NYC->Berlin").
+func tokenizeQuery(input string) []token {
+	var tokens []token
+	var word strings.Builder
+
+	flush := func() {
+		if word.Len() == 0 {
+			return
+		}
+		text := word.String()
+		tokens = append(tokens, token{Text: text, Separator: separatorWords[strings.ToLower(text)]})
+		word.Reset()
+	}
+
+	runes := []rune(strings.TrimSpace(input))
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			flush()
+		case r == '→':
+			flush()
+			tokens = append(tokens, token{Text: "→", Separator: true})
+		case r == '-' && i+1 < len(runes) && runes[i+1] == '>':
+			flush()
+			tokens = append(tokens, token{Text: "->", Separator: true})
+			i++
+		case r == ',':
+			flush()
+			tokens = append(tokens, token{Text: ","})
+		case r == '?' && i == len(runes)-1:
+			// Trailing question mark on question-style queries
+		default:
+			word.WriteRune(r)
+		}
+	}
+	flush()
+
+	return tokens
+}
+
+// joinTokens rebuilds the text covered by a run of tokens
+func joinTokens(tokens []token) string {
+	words := make([]string, len(tokens))
+	for i, t := range tokens {
+		words[i] = t.Text
+	}
+	return strings.Join(words, " ")
+}
+
+// hasPrefixWords reports whether tokens start with the given lowercase words
+func hasPrefixWords(tokens []token, words []string) bool {
+	if len(tokens) < len(words) {
+		return false
+	}
+	for i, w := range words {
+		if strings.ToLower(tokens[i].Text) != w {
+			return false
+		}
+	}
+	return true
+}
+
+// lookupZone resolves a zone span without building suggestions. A lone
+// separator word is never a zone, so "to" is not read as Toronto.
+func lookupZone(tokens []token) (*time.Location, bool) {
+	if len(tokens) == 0 || (len(tokens) == 1 && tokens[0].Separator) {
+		return nil, false
+	}
+
+	name := joinTokens(tokens)
+	if localZoneWords[strings.ToLower(name)] {
+		return time.Local, true
+	}
+
+	if cached, ok := zoneLookups.Load(name); ok {
+		found := cached.(zoneLookup)
+		return found.loc, found.ok
+	}
+	loc, ok := timezones.Lookup(name)
+	zoneLookups.Store(name, zoneLookup{loc: loc, ok: ok})
+	return loc, ok
+}
+
+// zoneLookup is a cached result of timezones.Lookup
+type zoneLookup struct {
+	loc *time.Location
+	ok  bool
+}
+
+// zoneLookups caches lookups by span text. The live preview tokenizes the
+// query on every keystroke, and a failed lookup tries several zone files.
+var zoneLookups sync.Map
+
+// resolveZone resolves a zone name, mapping "here", "local" and "my time" to
+// the user's own zone
+func resolveZone(name string) (*time.Location, error) {
+	if localZoneWords[strings.ToLower(strings.TrimSpace(name))] {
+		return time.Local, nil
+	}
+	return timezones.Resolve(name)
+}
+
+// zoneDisplayName returns the name to show for a zone typed by the user
+func zoneDisplayName(name string) string {
+	if localZoneWords[strings.ToLower(strings.TrimSpace(name))] {
+		return localZoneName
+	}
+	return name
+}
+
+// parseConversionQuery parses a Convert view query with the grammar
+//
+//	query  = [time] zone separator zone
+//	       | [time] zone ["time"] local
+//	       | question zone
+//
+// where separator is one of "to", "in", "as", "->" or "→", local is one of
+// the localZoneWords, and question is a phrase like "what time is it in".
+// Zone spans are matched against the city index, so multi-word cities and
+// cities containing separator words ("Barrow in Furness") are recognized.
+func parseConversionQuery(input string) (conversionQuery, error) {
+	tokens := tokenizeQuery(input)
+	if len(tokens) == 0 {
+		return conversionQuery{}, fmt.Errorf("Empty input")
+	}
+
+	// "what time is it in Tokyo"
+	for _, prefix := range questionPrefixes {
+		if hasPrefixWords(tokens, prefix) {
+			target := tokens[len(prefix):]
+			loc, ok := lookupZone(target)
+			if !ok {
+				return conversionQuery{}, zoneError(target)
+			}
+			return conversionQuery{
+				TimeExpr:   "now",
+				SourceZone: localZoneName,
+				SourceLoc:  time.Local,
+				Targets:    []conversionTarget{{Zone: zoneDisplayName(joinTokens(target)), Loc: loc}},
+			}, nil
+		}
+	}
+
+	// "when is 9am Tokyo in NYC"
+	if hasPrefixWords(tokens, []string{"when", "is"}) {
+		tokens = tokens[2:]
+	}
+
+	// Try each separator from left to right. Time expressions rarely end in a
+	// separator word, while target zones can contain one.
+	lastSeparator := -1
+	for k, t := range tokens {
+		if !t.Separator {
+			continue
+		}
+		lastSeparator = k
+
+		if query, ok := matchConversion(tokens[:k], tokens[k+1:]); ok {
+			return query, nil
+		}
+	}
+
+	// "9am Tokyo time here"
+	for k := 1; k < len(tokens); k++ {
+		target := tokens[k:]
+		if localZoneWords[strings.ToLower(joinTokens(target))] {
+			if query, ok := matchConversion(tokens[:k], target); ok {
+				return query, nil
+			}
+		}
+	}
+
+	if lastSeparator == -1 {
+		return conversionQuery{}, fmt.Errorf("Use format 'tomorrow 3pm NYC to Berlin' or '3pm NYC to Berlin'")
+	}
+	return conversionQuery{}, diagnoseConversion(tokens[:lastSeparator], tokens[lastSeparator], tokens[lastSeparator+1:])
+}
+
+// describeQuery explains how a partly typed query is being read, for the
+// live preview: "time: 3pm  •  from: NYC (America/New_York)  •  to: ?"
+func describeQuery(input string) string {
+	if query, ok, err := parseDurationQuery(input); ok {
+		if err != nil {
+			return ""
+		}
+		return fmt.Sprintf("from: %s  •  to: %s", describeEndpoint(query.From), describeEndpoint(query.To))
+	}
+
+	if query, err := parseConversionQuery(input); err == nil {
+		var targets []string
+		for _, t := range query.Targets {
+			targets = append(targets, describeZone(t.Zone, t.Loc))
+		}
+		if query.AllTargets {
+			targets = []string{"all clock zones"}
+		}
+		return fmt.Sprintf("time: %s  •  from: %s  •  to: %s",
+			query.TimeExpr,
+			describeZone(query.SourceZone, query.SourceLoc),
+			strings.Join(targets, ", "),
+		)
+	}
+
+	// Read the source side up to the first separator, if there is one
+	tokens := tokenizeQuery(input)
+	source := tokens
+	for k, t := range tokens {
+		if t.Separator {
+			source = tokens[:k]
+			break
+		}
+	}
+	source, _, _ = splitOffsets(source)
+	source = trimTimeFiller(source)
+
+	for i := 1; i < len(source); i++ {
+		if loc, ok := lookupZone(source[i:]); ok {
+			return fmt.Sprintf("time: %s  •  from: %s  •  to: ?",
+				joinTokens(source[:i]),
+				describeZone(zoneDisplayName(joinTokens(source[i:])), loc),
+			)
+		}
+	}
+
+	// Only a time so far: "3pm"
+	if len(source) > 0 {
+		if _, err := parseTimeWithContext(joinTokens(source), time.Now()); err == nil {
+			return fmt.Sprintf("time: %s  •  from: ?", joinTokens(source))
+		}
+	}
+	return ""
+}
+
+// describeEndpoint renders one side of a duration query for the preview
+func describeEndpoint(endpoint queryEndpoint) string {
+	return endpoint.TimeExpr + " " + describeZone(endpoint.Zone, endpoint.Loc)
+}
+
+// describeZone renders a zone as typed along with what it resolved to
+func describeZone(name string, loc *time.Location) string {
+	if name == loc.String() || loc == time.Local {
+		return name
+	}
+	return fmt.Sprintf("%s (%s)", name, loc)
+}
+
+// matchConversion matches the source side and target zone of a query. The
+// source side must end in a zone span with a non-empty time expression
+// before it; the longest matching span wins so "New York" beats "York".
+func matchConversion(source, target []token) (conversionQuery, bool) {
+	targets, all, ok := matchTargets(target)
+	if !ok {
+		return conversionQuery{}, false
+	}
+
+	source, offsets, ok := splitOffsets(source)
+	if !ok {
+		return conversionQuery{}, false
+	}
+
+	source = trimTimeFiller(source)
+	for i := 1; i < len(source); i++ {
+		sourceLoc, ok := lookupZone(source[i:])
+		if !ok {
+			continue
+		}
+
+		sourceZone := joinTokens(source[i:])
+		return conversionQuery{
+			TimeExpr:   joinTokens(source[:i]),
+			SourceZone: zoneDisplayName(sourceZone),
+			SourceLoc:  sourceLoc,
+			Targets:    targets,
+			AllTargets: all,
+			Offsets:    offsets,
+		}, true
+	}
+
+	return conversionQuery{}, false
+}
+
+// matchTargets matches the target side of a query: one zone, a list such as
+// "Berlin, Tokyo and Sydney", or "all" for the zones on the Clock view
+func matchTargets(tokens []token) ([]conversionTarget, bool, bool) {
+	if len(tokens) == 1 && strings.EqualFold(tokens[0].Text, "all") {
+		return nil, true, true
+	}
+
+	var targets []conversionTarget
+	for _, span := range splitTargetList(tokens) {
+		loc, ok := lookupZone(span)
+		if !ok {
+			return nil, false, false
+		}
+		targets = append(targets, conversionTarget{Zone: zoneDisplayName(joinTokens(span)), Loc: loc})
+	}
+
+	return targets, false, len(targets) > 0
+}
+
+// splitTargetList splits a target list at commas, and at "and" before the
+// last item: "Berlin, Tokyo and Sydney"
+func splitTargetList(tokens []token) [][]token {
+	var spans [][]token
+	start := 0
+	for i, t := range tokens {
+		if t.Text == "," {
+			if i > start {
+				spans = append(spans, tokens[start:i])
+			}
+			start = i + 1
+		}
+	}
+	last := tokens[start:]
+
+	// Keep "and" inside a zone name when the whole span resolves
+	if _, ok := lookupZone(last); !ok {
+		for i, t := range last {
+			if strings.EqualFold(t.Text, "and") {
+				spans = append(spans, last[:i])
+				last = last[i+1:]
+				break
+			}
+		}
+	}
+
+	if len(last) > 0 {
+		spans = append(spans, last)
+	}
+	return spans
+}
+
+// splitOffsets pulls "+ 90m" style terms out of the source side of a query.
+// They may follow the zone ("3pm NYC + 90m") or sit between the time and
+// the zone ("now - 6h UTC"); the remaining tokens are returned as one run.
+func splitOffsets(source []token) ([]token, []timeOffset, bool) {
+	start := -1
+	for i, t := range source {
+		if isOffsetOperator(t) {
+			start = i
+			break
+		}
+	}
+	if start == -1 {
+		return source, nil, true
+	}
+
+	var offsets []timeOffset
+	rest := source[start:]
+	for len(rest) > 0 && isOffsetOperator(rest[0]) {
+		offset, consumed, ok := parseOffset(rest)
+		if !ok {
+			return nil, nil, false
+		}
+		offsets = append(offsets, offset)
+		rest = rest[consumed:]
+	}
+
+	remaining := append(append([]token{}, source[:start]...), rest...)
+	return remaining, offsets, true
+}
+
+// isOffsetOperator reports whether t starts an offset term: "+", "-",
+// "plus", "minus", or a signed amount such as "+90m"
+func isOffsetOperator(t token) bool {
+	switch strings.ToLower(t.Text) {
+	case "+", "-", "plus", "minus":
+		return true
+	}
+	return offsetSignPattern.MatchString(t.Text)
+}
+
+var (
+	offsetSignPattern    = regexp.MustCompile(`^[+-]\d`)
+	compactOffsetPattern = regexp.MustCompile(`^(\d+)(d|w)$`)
+)
+
+// offsetUnits maps unit words to the offset they add per unit
+var offsetUnits = map[string]timeOffset{
+	"s": {Duration: time.Second}, "sec": {Duration: time.Second}, "secs": {Duration: time.Second},
+	"second": {Duration: time.Second}, "seconds": {Duration: time.Second},
+	"m": {Duration: time.Minute}, "min": {Duration: time.Minute}, "mins": {Duration: time.Minute},
+	"minute": {Duration: time.Minute}, "minutes": {Duration: time.Minute},
+	"h": {Duration: time.Hour}, "hr": {Duration: time.Hour}, "hrs": {Duration: time.Hour},
+	"hour": {Duration: time.Hour}, "hours": {Duration: time.Hour},
+	"d": {Days: 1}, "day": {Days: 1}, "days": {Days: 1},
+	"w": {Days: 7}, "wk": {Days: 7}, "wks": {Days: 7}, "week": {Days: 7}, "weeks": {Days: 7},
+}
+
+// parseOffset parses one offset term at the start of tokens and returns the
+// number of tokens it used. Accepted forms:
+// - Compact: "+ 90m", "-6h", "+ 1h30m", "+ 2d"
+// - Spelled out: "+ 90 minutes", "minus 3 days"
+// - Business days: "+ 2 business days", "- 1 working day"
+func parseOffset(tokens []token) (timeOffset, int, bool) {
+	offset := timeOffset{Sign: 1}
+
+	// Split the sign from the amount. It has a token of its own in "+ 90m"
+	// and shares one with the amount in "-6h".
+	var words []string
+	used := 1
+	first := strings.ToLower(tokens[0].Text)
+	switch first {
+	case "-", "minus":
+		offset.Sign = -1
+		fallthrough
+	case "+", "plus":
+		used = 2
+	default:
+		if first[0] == '-' {
+			offset.Sign = -1
+		}
+		words = append(words, first[1:])
+	}
+	for _, t := range tokens[1:] {
+		words = append(words, strings.ToLower(t.Text))
+	}
+
+	if len(words) == 0 {
+		return timeOffset{}, 0, false
+	}
+
+	// Compact amounts: "90m", "1h30m", "2d"
+	if matches := compactOffsetPattern.FindStringSubmatch(words[0]); matches != nil {
+		amount, _ := strconv.Atoi(matches[1])
+		offset.Days = amount * offsetUnits[matches[2]].Days
+		offset.Text = formatOffsetText(offset.Sign, words[:1])
+		return offset, used, true
+	}
+	if d, err := time.ParseDuration(words[0]); err == nil {
+		offset.Duration = d
+		offset.Text = formatOffsetText(offset.Sign, words[:1])
+		return offset, used, true
+	}
+
+	// Spelled-out amounts: "90 minutes", "2 business days"
+	amount, err := strconv.Atoi(words[0])
+	if err != nil || len(words) < 2 {
+		return timeOffset{}, 0, false
+	}
+
+	if (words[1] == "business" || words[1] == "working") && len(words) >= 3 &&
+		(words[2] == "day" || words[2] == "days") {
+		offset.BusinessDays = amount
+		offset.Text = formatOffsetText(offset.Sign, words[:3])
+		return offset, used + 2, true
+	}
+
+	unit, ok := offsetUnits[words[1]]
+	if !ok {
+		return timeOffset{}, 0, false
+	}
+	offset.Duration = time.Duration(amount) * unit.Duration
+	offset.Days = amount * unit.Days
+	offset.Text = formatOffsetText(offset.Sign, words[:2])
+	return offset, used + 1, true
+}
+
+// formatOffsetText renders an offset term for display: "+ 90m"
+func formatOffsetText(sign int, words []string) string {
+	op := "+"
+	if sign < 0 {
+		op = "-"
+	}
+	return op + " " + strings.Join(words, " ")
+}
+
+// trimTimeFiller drops the filler word in "9am Tokyo time"
+func trimTimeFiller(source []token) []token {
+	if len(source) > 2 && strings.EqualFold(source[len(source)-1].Text, "time") {
+		return source[:len(source)-1]
+	}
+	return source
+}
+
+// diagnoseConversion explains why a query split at separator did not match
+func diagnoseConversion(source []token, separator token, target []token) error {
+	if len(target) == 0 {
+		return fmt.Errorf("Specify a target zone after '%s'", separator.Text)
+	}
+	if _, _, ok := matchTargets(target); !ok {
+		for _, span := range splitTargetList(target) {
+			if _, ok := lookupZone(span); !ok {
+				return zoneError(span)
+			}
+		}
+	}
+
+	source, _, ok := splitOffsets(source)
+	if !ok {
+		return fmt.Errorf("Write offsets like '+ 90m', '- 6h' or '+ 2 business days'")
+	}
+
+	source = trimTimeFiller(source)
+	if len(source) < 2 {
+		return fmt.Errorf("Specify time and source zone")
+	}
+	return zoneError(source[len(source)-1:])
+}
+
+// zoneError returns the resolver's error for a zone span, including its
+// suggestions for likely typos
+func zoneError(tokens []token) error {
+	if len(tokens) == 0 {
+		return fmt.Errorf("Specify a zone")
+	}
+	if len(tokens) == 1 && tokens[0].Separator {
+		return fmt.Errorf("'%s' is not a zone", tokens[0].Text)
+	}
+	_, err := resolveZone(joinTokens(tokens))
+	return err
+}
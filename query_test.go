@@ -0,0 +1,517 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTokenizeQuery(t *testing.T) {
+	tokens := tokenizeQuery("3pm NYC->New York to Berlin?")
+
+	want := []token{
+		{Text: "3pm"},
+		{Text: "NYC"},
+		{Text: "->", Separator: true},
+		{Text: "New"},
+		{Text: "York"},
+		{Text: "to", Separator: true},
+		{Text: "Berlin"},
+	}
+
+	if len(tokens) != len(want) {
+		t.Fatalf("got %d tokens %v, want %d", len(tokens), tokens, len(want))
+	}
+	for i := range want {
+		if tokens[i] != want[i] {
+			t.Errorf("token %d = %+v, want %+v", i, tokens[i], want[i])
+		}
+	}
+}
+
+func TestLookupZoneCached(t *testing.T) {
+	for _, input := range []string{"Kuala Lumpur", "Nowhereville"} {
+		tokens := tokenizeQuery(input)
+		loc, ok := lookupZone(tokens)
+
+		if _, cached := zoneLookups.Load(input); !cached {
+			t.Errorf("lookupZone(%q) was not cached", input)
+		}
+		again, okAgain := lookupZone(tokens)
+		if again != loc || okAgain != ok {
+			t.Errorf("lookupZone(%q) = %v, %v, then %v, %v", input, loc, ok, again, okAgain)
+		}
+	}
+}
+
+func TestParseConversionQuery(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        string
+		wantTimeExpr string
+		wantSource   string
+		wantTarget   string
+		wantError    bool
+	}{
+		{
+			name:         "plain conversion",
+			input:        "tomorrow 3pm NYC to Berlin",
+			wantTimeExpr: "tomorrow 3pm",
+			wantSource:   "NYC",
+			wantTarget:   "Berlin",
+		},
+		{
+			name:         "what time is it",
+			input:        "what time is it in Tokyo?",
+			wantTimeExpr: "now",
+			wantSource:   localZoneName,
+			wantTarget:   "Tokyo",
+		},
+		{
+			name:         "when is",
+			input:        "when is 9am Tokyo in NYC",
+			wantTimeExpr: "9am",
+			wantSource:   "Tokyo",
+			wantTarget:   "NYC",
+		},
+		{
+			name:         "when is in my time",
+			input:        "When is 9am Tokyo time in my time?",
+			wantTimeExpr: "9am",
+			wantSource:   "Tokyo",
+			wantTarget:   localZoneName,
+		},
+		{
+			name:         "time here",
+			input:        "9am Tokyo time here",
+			wantTimeExpr: "9am",
+			wantSource:   "Tokyo",
+			wantTarget:   localZoneName,
+		},
+		{
+			name:         "to local",
+			input:        "3pm Berlin to local",
+			wantTimeExpr: "3pm",
+			wantSource:   "Berlin",
+			wantTarget:   localZoneName,
+		},
+		{
+			name:         "multi-word source zone",
+			input:        "next monday noon Los Angeles to Hong Kong",
+			wantTimeExpr: "next monday noon",
+			wantSource:   "Los Angeles",
+			wantTarget:   "Hong Kong",
+		},
+		{
+			name:         "in separator",
+			input:        "3pm NYC in Berlin",
+			wantTimeExpr: "3pm",
+			wantSource:   "NYC",
+			wantTarget:   "Berlin",
+		},
+		{
+			name:         "arrow separator",
+			input:        "3pm NYC -> Berlin",
+			wantTimeExpr: "3pm",
+			wantSource:   "NYC",
+			wantTarget:   "Berlin",
+		},
+		{
+			name:         "unicode arrow without spaces",
+			input:        "3pm NYC→Berlin",
+			wantTimeExpr: "3pm",
+			wantSource:   "NYC",
+			wantTarget:   "Berlin",
+		},
+		{
+			name:         "as separator",
+			input:        "9:30am Tokyo as London",
+			wantTimeExpr: "9:30am",
+			wantSource:   "Tokyo",
+			wantTarget:   "London",
+		},
+		{
+			name:         "relative time starting with in",
+			input:        "in 2 hours Tokyo to NYC",
+			wantTimeExpr: "in 2 hours",
+			wantSource:   "Tokyo",
+			wantTarget:   "NYC",
+		},
+		{
+			name:         "city containing a separator word",
+			input:        "3pm Barrow in Furness to Berlin",
+			wantTimeExpr: "3pm",
+			wantSource:   "Barrow in Furness",
+			wantTarget:   "Berlin",
+		},
+		{
+			name:         "toronto as a city",
+			input:        "3pm Toronto to Berlin",
+			wantTimeExpr: "3pm",
+			wantSource:   "Toronto",
+			wantTarget:   "Berlin",
+		},
+		{
+			name:      "separator is not a zone",
+			input:     "3pm Toronto to to",
+			wantError: true,
+		},
+		{
+			name:      "missing time",
+			input:     "NYC to Berlin",
+			wantError: true,
+		},
+		{
+			name:      "missing separator",
+			input:     "3pm NYC Berlin",
+			wantError: true,
+		},
+		{
+			name:      "unknown target",
+			input:     "3pm NYC to Qwzxville",
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := parseConversionQuery(tt.input)
+
+			if tt.wantError {
+				if err == nil {
+					t.Errorf("expected error but got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if query.TimeExpr != tt.wantTimeExpr {
+				t.Errorf("time expression = %q, want %q", query.TimeExpr, tt.wantTimeExpr)
+			}
+			if query.SourceZone != tt.wantSource {
+				t.Errorf("source zone = %q, want %q", query.SourceZone, tt.wantSource)
+			}
+			if len(query.Targets) != 1 {
+				t.Fatalf("got %d targets, want 1", len(query.Targets))
+			}
+			if query.Targets[0].Zone != tt.wantTarget {
+				t.Errorf("target zone = %q, want %q", query.Targets[0].Zone, tt.wantTarget)
+			}
+			if tt.wantTarget == localZoneName && query.Targets[0].Loc != time.Local {
+				t.Errorf("target location = %v, want Local", query.Targets[0].Loc)
+			}
+		})
+	}
+}
+
+func TestParseConversionQueryOffsets(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skipf("tzdata not available: %v", err)
+	}
+	base := time.Date(2026, 1, 20, 9, 0, 0, 0, tokyo) // Tuesday
+
+	tests := []struct {
+		name         string
+		input        string
+		wantTimeExpr string
+		wantSource   string
+		wantResult   time.Time
+		wantError    bool
+	}{
+		{
+			name:         "minutes after the zone",
+			input:        "9am Tokyo + 90m to Berlin",
+			wantTimeExpr: "9am",
+			wantSource:   "Tokyo",
+			wantResult:   base.Add(90 * time.Minute),
+		},
+		{
+			name:         "hours before the zone",
+			input:        "now - 6h Tokyo to PST",
+			wantTimeExpr: "now",
+			wantSource:   "Tokyo",
+			wantResult:   base.Add(-6 * time.Hour),
+		},
+		{
+			name:         "business days",
+			input:        "2026-01-20 09:00 Tokyo + 2 business days to London",
+			wantTimeExpr: "2026-01-20 09:00",
+			wantSource:   "Tokyo",
+			wantResult:   time.Date(2026, 1, 22, 9, 0, 0, 0, tokyo),
+		},
+		{
+			name:         "chained compact offsets",
+			input:        "9am Tokyo +1h30m -2d to NYC",
+			wantTimeExpr: "9am",
+			wantSource:   "Tokyo",
+			wantResult:   time.Date(2026, 1, 18, 10, 30, 0, 0, tokyo),
+		},
+		{
+			name:         "spelled out",
+			input:        "9am Tokyo plus 3 hours to NYC",
+			wantTimeExpr: "9am",
+			wantSource:   "Tokyo",
+			wantResult:   base.Add(3 * time.Hour),
+		},
+		{
+			name:      "invalid offset",
+			input:     "9am Tokyo + soon to NYC",
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := parseConversionQuery(tt.input)
+
+			if tt.wantError {
+				if err == nil {
+					t.Errorf("expected error but got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if query.TimeExpr != tt.wantTimeExpr {
+				t.Errorf("time expression = %q, want %q", query.TimeExpr, tt.wantTimeExpr)
+			}
+			if query.SourceZone != tt.wantSource {
+				t.Errorf("source zone = %q, want %q", query.SourceZone, tt.wantSource)
+			}
+
+			result := base
+			for _, offset := range query.Offsets {
+				result = offset.apply(result)
+			}
+			if !result.Equal(tt.wantResult) {
+				t.Errorf("result = %v, want %v", result, tt.wantResult)
+			}
+		})
+	}
+}
+
+func TestResolveTimeExprDayOffsetDST(t *testing.T) {
+	nyc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("tzdata not available: %v", err)
+	}
+	now := time.Date(2026, 1, 20, 9, 0, 0, 0, nyc)
+
+	tests := []struct {
+		name            string
+		input           string
+		wantTime        time.Time
+		wantGap         bool
+		wantOverlap     bool
+		wantWallOffsets int
+	}{
+		{
+			name:     "day into a gap",
+			input:    "2026-03-07 02:30 NYC + 1d to London",
+			wantTime: time.Date(2026, 3, 8, 3, 30, 0, 0, nyc),
+			wantGap:  true,
+		},
+		{
+			name:     "business day into a gap",
+			input:    "2026-03-06 02:30 NYC + 1 business day to London",
+			wantTime: time.Date(2026, 3, 9, 2, 30, 0, 0, nyc),
+		},
+		{
+			name:            "day into an overlap, then hours",
+			input:           "2026-10-31 01:30 NYC + 1d + 2h to London",
+			wantTime:        time.Date(2026, 11, 1, 5, 30, 0, 0, time.UTC).Add(2 * time.Hour),
+			wantOverlap:     true,
+			wantWallOffsets: 1,
+		},
+		{
+			name:            "hours keep elapsed time",
+			input:           "2026-03-08 01:30 NYC + 1h to London",
+			wantTime:        time.Date(2026, 3, 8, 3, 30, 0, 0, nyc),
+			wantWallOffsets: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := parseConversionQuery(tt.input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			resolved, err := resolveTimeExpr(query.TimeExpr, query.SourceLoc, query.Offsets, now, parseOptions{})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !resolved.Time.Equal(tt.wantTime) {
+				t.Errorf("result = %v, want %v", resolved.Time, tt.wantTime)
+			}
+			if resolved.Wall.Gap != tt.wantGap {
+				t.Errorf("gap = %v, want %v", resolved.Wall.Gap, tt.wantGap)
+			}
+			if resolved.Wall.Overlap() != tt.wantOverlap {
+				t.Errorf("overlap = %v, want %v", resolved.Wall.Overlap(), tt.wantOverlap)
+			}
+			if len(resolved.WallOffsets) != tt.wantWallOffsets {
+				t.Errorf("offsets after the wall time = %d, want %d", len(resolved.WallOffsets), tt.wantWallOffsets)
+			}
+		})
+	}
+}
+
+func TestParseConversionQueryTargets(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		wantTargets []string
+		wantAll     bool
+		wantError   bool
+	}{
+		{
+			name:        "comma list",
+			input:       "3pm NYC to Berlin, Tokyo, Sydney",
+			wantTargets: []string{"Berlin", "Tokyo", "Sydney"},
+		},
+		{
+			name:        "no spaces after commas",
+			input:       "3pm NYC to Berlin,Tokyo",
+			wantTargets: []string{"Berlin", "Tokyo"},
+		},
+		{
+			name:        "and before the last item",
+			input:       "3pm NYC to Berlin, Los Angeles and Tokyo",
+			wantTargets: []string{"Berlin", "Los Angeles", "Tokyo"},
+		},
+		{
+			name:        "local in a list",
+			input:       "9am Tokyo in London, here",
+			wantTargets: []string{"London", localZoneName},
+		},
+		{
+			name:        "trailing comma",
+			input:       "3pm NYC to Berlin,",
+			wantTargets: []string{"Berlin"},
+		},
+		{
+			name:    "clock zones",
+			input:   "3pm NYC to all",
+			wantAll: true,
+		},
+		{
+			name:      "unknown zone in list",
+			input:     "3pm NYC to Berlin, Qwzxville",
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := parseConversionQuery(tt.input)
+
+			if tt.wantError {
+				if err == nil {
+					t.Errorf("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if query.AllTargets != tt.wantAll {
+				t.Errorf("all targets = %v, want %v", query.AllTargets, tt.wantAll)
+			}
+			if len(query.Targets) != len(tt.wantTargets) {
+				t.Fatalf("got targets %+v, want %v", query.Targets, tt.wantTargets)
+			}
+			for i, want := range tt.wantTargets {
+				if query.Targets[i].Zone != want {
+					t.Errorf("target %d = %q, want %q", i, query.Targets[i].Zone, want)
+				}
+			}
+		})
+	}
+}
+
+func TestDescribeQuery(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"3pm", "time: 3pm  •  from: ?"},
+		{"3pm NYC", "time: 3pm  •  from: NYC (America/New_York)  •  to: ?"},
+		{"3pm NYC to", "time: 3pm  •  from: NYC (America/New_York)  •  to: ?"},
+		{"3pm NYC to Berlin", "time: 3pm  •  from: NYC (America/New_York)  •  to: Berlin (Europe/Berlin)"},
+		{"3pm Tokyo to all", "time: 3pm  •  from: Tokyo (Asia/Tokyo)  •  to: all clock zones"},
+		{"how long until 9am Tokyo", "from: now Local  •  to: 9am Tokyo (Asia/Tokyo)"},
+		{"qwzx", ""},
+	}
+
+	for _, tt := range tests {
+		if got := describeQuery(tt.input); got != tt.want {
+			t.Errorf("describeQuery(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestConversionQueryString(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"3pm NYC to Berlin", "3pm NYC to Berlin"},
+		{"3pm NYC -> Berlin, Tokyo", "3pm NYC to Berlin, Tokyo"},
+		{"3pm NYC + 90m to Berlin", "3pm NYC + 90m to Berlin"},
+		{"what time is it in Tokyo", "now " + localZoneName + " to Tokyo"},
+		{"3pm NYC to all", "3pm NYC to all"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			query, err := parseConversionQuery(tt.input)
+			if err != nil {
+				t.Fatalf("parseConversionQuery(%q) error: %v", tt.input, err)
+			}
+			if got := query.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+			if _, err := parseConversionQuery(query.String()); err != nil {
+				t.Errorf("String() = %q does not parse: %v", query.String(), err)
+			}
+		})
+	}
+}
+
+func TestConversionQuerySwapped(t *testing.T) {
+	tests := []struct {
+		input  string
+		want   string
+		wantOK bool
+	}{
+		{"3pm NYC to Berlin", "3pm Berlin to NYC", true},
+		{"9am Tokyo + 2h to London", "9am London + 2h to Tokyo", true},
+		{"3pm NYC to Berlin, Tokyo", "", false},
+		{"3pm NYC to all", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			query, err := parseConversionQuery(tt.input)
+			if err != nil {
+				t.Fatalf("parseConversionQuery(%q) error: %v", tt.input, err)
+			}
+			swapped, ok := query.swapped()
+			if ok != tt.wantOK {
+				t.Fatalf("swapped() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && swapped.String() != tt.want {
+				t.Errorf("swapped() = %q, want %q", swapped.String(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,312 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// rotationSpec is a recurring meeting: "weekly tuesday 8"
+type rotationSpec struct {
+	// Every is the number of days between meetings, 7 or 14
+	Every   int
+	Weekday time.Weekday
+	Count   int
+}
+
+// defaultRotationCount is how many meetings a rotation plans
+const defaultRotationCount = 8
+
+// rotationCadences maps cadence words to days between meetings
+var rotationCadences = map[string]int{
+	"weekly":      7,
+	"biweekly":    14,
+	"fortnightly": 14,
+}
+
+// parseRotation reads rotation options such as "weekly", "biweekly
+// thursday" or "weekly on tue 12". The day defaults to tomorrow's weekday
+// and the count to 8.
+func parseRotation(options string, now time.Time) (rotationSpec, bool, error) {
+	words := strings.Fields(strings.ToLower(options))
+	if len(words) == 0 {
+		return rotationSpec{}, false, nil
+	}
+	every, ok := rotationCadences[words[0]]
+	if !ok {
+		return rotationSpec{}, false, nil
+	}
+
+	spec := rotationSpec{Every: every, Weekday: now.AddDate(0, 0, 1).Weekday(), Count: defaultRotationCount}
+	for _, word := range words[1:] {
+		if word == "on" {
+			continue
+		}
+		if weekday, ok := weekdayNames[strings.TrimSuffix(word, "s")]; ok {
+			spec.Weekday = weekday
+			continue
+		}
+		if count, err := strconv.Atoi(strings.TrimSuffix(word, "x")); err == nil && count > 0 && count <= 52 {
+			spec.Count = count
+			continue
+		}
+		return rotationSpec{}, true, fmt.Errorf("Unknown rotation option '%s'. Try: weekly tuesday 8: NYC, London, Tokyo", word)
+	}
+	return spec, true, nil
+}
+
+// rotationDates returns the local dates of the meetings, starting with the
+// first matching weekday after today
+func rotationDates(spec rotationSpec, now time.Time) []time.Time {
+	first := now.AddDate(0, 0, 1)
+	first = first.AddDate(0, 0, (int(spec.Weekday)-int(first.Weekday())+7)%7)
+
+	dates := make([]time.Time, spec.Count)
+	for i := range dates {
+		dates[i] = first.AddDate(0, 0, i*spec.Every)
+	}
+	return dates
+}
+
+// planRotation picks a slot on each date so that inconvenience takes turns.
+// Each meeting goes to the slot that keeps the participants' running costs
+// most even, measured as the sum of their squares, so one zone doesn't take
+// every bad slot. Slots are scored on their own date, so DST changes during
+// the rotation move them with local time.
+func planRotation(participants []participant, dates []time.Time, length time.Duration, config MeetingConfig) []suggestion {
+	running := make([]float64, len(participants))
+	plan := make([]suggestion, 0, len(dates))
+	for _, date := range dates {
+		from, to := dayBounds(date, date.Location())
+
+		var best suggestion
+		bestSpread := -1.0
+		for _, candidate := range scoreSlots(participants, from, to, length, config) {
+			spread := 0.0
+			for i, cost := range candidate.Costs {
+				spread += (running[i] + cost.Cost) * (running[i] + cost.Cost)
+			}
+			if bestSpread < 0 || spread < bestSpread || (spread == bestSpread && candidate.Total < best.Total) {
+				best, bestSpread = candidate, spread
+			}
+		}
+
+		for i, cost := range best.Costs {
+			running[i] += cost.Cost
+		}
+		plan = append(plan, best)
+	}
+	return plan
+}
+
+// renderRotation shows a planned rotation as a table, with each
+// participant's time, their running costs and DST changes along the way
+func (m model) renderRotation(participants []participant, spec rotationSpec, plan []suggestion, length time.Duration) string {
+	cadence := "Weekly"
+	if spec.Every == 14 {
+		cadence = "Every other week"
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s on %ss, %s of %s, taking turns with inconvenient times:\n\n",
+		cadence, spec.Weekday, pluralize(len(plan), "meeting"), formatShortDuration(length))
+
+	header := []string{"#", "Date", "Your time"}
+	for _, p := range participants {
+		header = append(header, p.Name)
+	}
+	rows := [][]string{append(header, "Cost")}
+
+	totals := make([]float64, len(participants))
+	var notes []string
+	for n, s := range plan {
+		local := s.Slot.Start.In(time.Local)
+		row := []string{strconv.Itoa(n + 1), m.format.ShortDate(local), m.format.Time(local)}
+		for i, p := range participants {
+			cell := m.format.Time(s.Slot.Start.In(p.Loc))
+			switch {
+			case s.Costs[i].Night > 0:
+				cell += " **"
+			case s.Costs[i].OffHours > 0:
+				cell += " *"
+			}
+			row = append(row, cell)
+			totals[i] += s.Costs[i].Cost
+
+			if n > 0 {
+				before, after := plan[n-1].Slot.Start.In(p.Loc), s.Slot.Start.In(p.Loc)
+				if dstShift(before, after, p.Loc) != 0 {
+					notes = append(notes, fmt.Sprintf("Clocks in %s change before meeting %d (%s to %s)",
+						p.Name, n+1, zoneAbbreviationOrOffset(before), zoneAbbreviationOrOffset(after)))
+				}
+			}
+		}
+		rows = append(rows, append(row, fmt.Sprintf("%.1f", s.Total)))
+	}
+
+	totalRow := []string{"", "Total", ""}
+	sum := 0.0
+	for _, total := range totals {
+		totalRow = append(totalRow, fmt.Sprintf("%.1f", total))
+		sum += total
+	}
+	rows = append(rows, append(totalRow, fmt.Sprintf("%.1f", sum)))
+
+	b.WriteString(formatTable(rows, "  "))
+	b.WriteString("\n\n  * outside working hours  ** at night")
+	for _, note := range notes {
+		b.WriteString("\n  ⚠️  " + note)
+	}
+	return b.String()
+}
+
+// zoneAbbreviationOrOffset names t's zone by abbreviation, or by UTC offset
+// when it has none
+func zoneAbbreviationOrOffset(t time.Time) string {
+	if abbreviation := zoneAbbreviation(t); abbreviation != "" {
+		return abbreviation
+	}
+	return formatUTCOffset(t)
+}
+
+// rotationICS renders a planned rotation as an iCalendar file
+func rotationICS(participants []participant, plan []suggestion, f timeFormatter, now time.Time) string {
+	const stamp = "20060102T150405Z"
+
+	names := make([]string, len(participants))
+	for i, p := range participants {
+		names[i] = p.Name
+	}
+
+	lines := []string{"BEGIN:VCALENDAR", "VERSION:2.0", "PRODID:-//aeon//meeting rotation//EN", "CALSCALE:GREGORIAN"}
+	for n, s := range plan {
+		times := make([]string, len(participants))
+		for i, p := range participants {
+			times[i] = p.Name + " " + f.ShortDateTime(s.Slot.Start.In(p.Loc))
+		}
+
+		lines = append(lines,
+			"BEGIN:VEVENT",
+			fmt.Sprintf("UID:%s-%d@aeon", s.Slot.Start.UTC().Format(stamp), n+1),
+			"DTSTAMP:"+now.UTC().Format(stamp),
+			"DTSTART:"+s.Slot.Start.UTC().Format(stamp),
+			"DTEND:"+s.Slot.End.UTC().Format(stamp),
+			"SUMMARY:"+escapeICS(fmt.Sprintf("Meeting %d of %d: %s", n+1, len(plan), strings.Join(names, ", "))),
+			"DESCRIPTION:"+escapeICS(strings.Join(times, "\n")),
+			"END:VEVENT",
+		)
+	}
+	lines = append(lines, "END:VCALENDAR")
+
+	var b strings.Builder
+	for _, line := range lines {
+		b.WriteString(foldICSLine(line))
+		b.WriteString("\r\n")
+	}
+	return b.String()
+}
+
+// escapeICS escapes text for an iCalendar property value
+func escapeICS(text string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`).Replace(text)
+}
+
+// foldICSLine splits a content line into lines of at most 75 bytes, as
+// iCalendar requires, without breaking UTF-8 sequences
+func foldICSLine(line string) string {
+	var b strings.Builder
+	width := 0
+	for _, r := range line {
+		size := len(string(r))
+		if width+size > 75 {
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += size
+	}
+	return b.String()
+}
+
+// planQueryRotation plans the rotation of a meeting query, starting after
+// today
+func (m model) planQueryRotation(query meetingQuery) []suggestion {
+	dates := rotationDates(*query.Rotation, time.Now().In(time.Local))
+	return planRotation(query.Participants, dates, query.slotLength(), m.meetingConfig)
+}
+
+// rotationFile is the name of an exported rotation. When a file of that
+// name exists, a number is added: "aeon-rotation-2.ics".
+const rotationFile = "aeon-rotation"
+
+// exportDir returns the directory exported files are written to: the
+// configured one, with "~" standing for the home directory, or aeon's
+// config directory
+func (c MeetingConfig) exportDir() (string, error) {
+	if c.ExportDir != "" {
+		if rest, ok := strings.CutPrefix(c.ExportDir, "~"); ok && (rest == "" || rest[0] == '/') {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return "", err
+			}
+			return filepath.Join(home, rest), nil
+		}
+		return c.ExportDir, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "aeon"), nil
+}
+
+// createNew creates a file for writing in dir, named name+ext or, when
+// that is taken, name-2+ext and so on. Existing files are never touched.
+func createNew(dir, name, ext string) (*os.File, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	for n := 1; ; n++ {
+		path := filepath.Join(dir, name+ext)
+		if n > 1 {
+			path = filepath.Join(dir, fmt.Sprintf("%s-%d%s", name, n, ext))
+		}
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if !os.IsExist(err) {
+			return file, err
+		}
+	}
+}
+
+// exportRotation writes the rotation shown in the Meeting view to a new
+// file in the export directory and returns a notice with its full path
+func (m model) exportRotation() (string, error) {
+	if m.lastMeeting.Rotation == nil {
+		return "", fmt.Errorf("Plan a rotation first, such as: weekly tuesday 8: NYC, London, Tokyo")
+	}
+
+	dir, err := m.meetingConfig.exportDir()
+	if err != nil {
+		return "", fmt.Errorf("Could not find a directory to save the rotation in: %v", err)
+	}
+	if dir, err = filepath.Abs(dir); err != nil {
+		return "", err
+	}
+	file, err := createNew(dir, rotationFile, ".ics")
+	if err != nil {
+		return "", fmt.Errorf("Could not save the rotation: %v", err)
+	}
+
+	ics := rotationICS(m.lastMeeting.Participants, m.meetingPlan, m.format, time.Now())
+	_, err = file.WriteString(ics)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", fmt.Errorf("Could not save the rotation: %v", err)
+	}
+	return fmt.Sprintf("Saved %s to %s", pluralize(len(m.meetingPlan), "meeting"), file.Name()), nil
+}
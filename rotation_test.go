@@ -0,0 +1,206 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseRotation(t *testing.T) {
+	// A Sunday, so rotations default to Mondays
+	now := time.Date(2026, 1, 18, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		options   string
+		want      rotationSpec
+		wantOK    bool
+		wantError bool
+	}{
+		{options: "weekly", want: rotationSpec{Every: 7, Weekday: time.Monday, Count: 8}, wantOK: true},
+		{options: "Weekly Tuesday 6", want: rotationSpec{Every: 7, Weekday: time.Tuesday, Count: 6}, wantOK: true},
+		{options: "biweekly on thursdays", want: rotationSpec{Every: 14, Weekday: time.Thursday, Count: 8}, wantOK: true},
+		{options: "fortnightly fri 12x", want: rotationSpec{Every: 14, Weekday: time.Friday, Count: 12}, wantOK: true},
+		{options: "weekly tues", want: rotationSpec{Every: 7, Weekday: time.Tuesday, Count: 8}, wantOK: true},
+		{options: "weekly tuesday 0", wantOK: true, wantError: true},
+		{options: "weekly sometimes", wantOK: true, wantError: true},
+		{options: "daily", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.options, func(t *testing.T) {
+			got, ok, err := parseRotation(tt.options, now)
+			if ok != tt.wantOK {
+				t.Fatalf("parseRotation(%q) ok = %v, want %v", tt.options, ok, tt.wantOK)
+			}
+			if (err != nil) != tt.wantError {
+				t.Fatalf("parseRotation(%q) error = %v, want error %v", tt.options, err, tt.wantError)
+			}
+			if err == nil && got != tt.want {
+				t.Errorf("parseRotation(%q) = %+v, want %+v", tt.options, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRotationDates(t *testing.T) {
+	spec := rotationSpec{Every: 14, Weekday: time.Tuesday, Count: 3}
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want []string
+	}{
+		{"from a sunday", time.Date(2026, 1, 18, 12, 0, 0, 0, time.UTC), []string{"2026-01-20", "2026-02-03", "2026-02-17"}},
+		{"from the same weekday", time.Date(2026, 1, 20, 12, 0, 0, 0, time.UTC), []string{"2026-01-27", "2026-02-10", "2026-02-24"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dates := rotationDates(spec, tt.now)
+			for i, want := range tt.want {
+				if got := dates[i].Format("2006-01-02"); got != want {
+					t.Errorf("date %d = %s, want %s", i+1, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestPlanRotation(t *testing.T) {
+	load := func(name, label string) participant {
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			t.Fatalf("LoadLocation(%q): %v", name, err)
+		}
+		return participant{Name: label, Loc: loc, Hours: defaultWorkingHours}
+	}
+	nyc, london, tokyo := load("America/New_York", "NYC"), load("Europe/London", "London"), load("Asia/Tokyo", "Tokyo")
+	weekly := func(from time.Time, count int) []time.Time {
+		return rotationDates(rotationSpec{Every: 7, Weekday: time.Tuesday, Count: count}, from)
+	}
+
+	t.Run("takes turns", func(t *testing.T) {
+		// NYC's evening is always the cheapest slot; taking it every week
+		// would put all the inconvenience on NYC
+		plan := planRotation([]participant{nyc, tokyo}, weekly(time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC), 8), time.Hour, MeetingConfig{})
+		totals := make([]float64, 2)
+		for _, s := range plan {
+			for i, cost := range s.Costs {
+				totals[i] += cost.Cost
+			}
+		}
+		if totals[0] == 0 || totals[1] == 0 {
+			t.Errorf("costs = %v, want inconvenience shared by both zones", totals)
+		}
+		if totals[0] >= 8 {
+			t.Errorf("NYC cost = %g, want less than a bad slot every week", totals[0])
+		}
+	})
+
+	t.Run("follows DST", func(t *testing.T) {
+		// NYC moves to EDT on March 8, three weeks before London moves to
+		// BST, and the meeting keeps its local hour in NYC
+		plan := planRotation([]participant{nyc, london}, weekly(time.Date(2026, 2, 23, 0, 0, 0, 0, time.UTC), 6), time.Hour, MeetingConfig{})
+		for i, s := range plan {
+			if s.Total != 0 {
+				t.Errorf("meeting %d costs %g, want a slot within working hours", i+1, s.Total)
+			}
+			if hour := s.Slot.Start.In(nyc.Loc).Hour(); hour != 9 {
+				t.Errorf("meeting %d starts at %d:00 in NYC, want 9:00", i+1, hour)
+			}
+		}
+	})
+}
+
+func TestRotationICS(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	participants := []participant{
+		{Name: "Berlin", Loc: loc},
+		{Name: "Los Angeles; West Coast office with a long name", Loc: time.UTC},
+	}
+	start := time.Date(2026, 1, 20, 14, 0, 0, 0, time.UTC)
+	plan := []suggestion{
+		{Slot: interval{Start: start, End: start.Add(time.Hour)}},
+		{Slot: interval{Start: start.AddDate(0, 0, 7), End: start.AddDate(0, 0, 7).Add(time.Hour)}},
+	}
+
+	ics := rotationICS(participants, plan, defaultFormatter, start)
+	if !strings.HasPrefix(ics, "BEGIN:VCALENDAR\r\n") || !strings.HasSuffix(ics, "END:VCALENDAR\r\n") {
+		t.Errorf("rotationICS() is not a calendar:\n%s", ics)
+	}
+	if n := strings.Count(ics, "BEGIN:VEVENT\r\n"); n != 2 {
+		t.Errorf("rotationICS() has %d events, want 2", n)
+	}
+	for _, want := range []string{"DTSTART:20260120T140000Z\r\n", "DTEND:20260127T150000Z\r\n", `Los Angeles\; West`} {
+		if !strings.Contains(strings.ReplaceAll(ics, "\r\n ", ""), want) {
+			t.Errorf("rotationICS() is missing %q:\n%s", want, ics)
+		}
+	}
+	for _, line := range strings.Split(ics, "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("line longer than 75 bytes: %q", line)
+		}
+	}
+}
+
+func TestExportRotation(t *testing.T) {
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "aeon-rotation.ics")
+	if err := os.WriteFile(existing, []byte("keep me"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Date(2026, 1, 20, 14, 0, 0, 0, time.UTC)
+	m := model{
+		format:        defaultFormatter,
+		meetingConfig: MeetingConfig{ExportDir: dir},
+		lastMeeting: meetingQuery{
+			Participants: []participant{{Name: "UTC", Loc: time.UTC}},
+			Rotation:     &rotationSpec{Every: 7, Weekday: time.Tuesday, Count: 1},
+		},
+		// The plan on screen, which is exported as is
+		meetingPlan: []suggestion{{Slot: interval{Start: start, End: start.Add(time.Hour)}}},
+	}
+
+	for _, name := range []string{"aeon-rotation-2.ics", "aeon-rotation-3.ics"} {
+		notice, err := m.exportRotation()
+		if err != nil {
+			t.Fatalf("exportRotation() error: %v", err)
+		}
+		path := filepath.Join(dir, name)
+		if !strings.HasSuffix(notice, " to "+path) {
+			t.Errorf("exportRotation() notice = %q, want the path %s", notice, path)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(data), "DTSTART:20260120T140000Z") {
+			t.Errorf("%s doesn't hold the plan on screen:\n%s", name, data)
+		}
+	}
+	if data, _ := os.ReadFile(existing); string(data) != "keep me" {
+		t.Errorf("exportRotation() overwrote %s", existing)
+	}
+
+	// A relative directory is shown as a full path
+	t.Chdir(dir)
+	m.meetingConfig.ExportDir = "calendars"
+	notice, err := m.exportRotation()
+	if err != nil {
+		t.Fatalf("exportRotation() error: %v", err)
+	}
+	if want := filepath.Join(dir, "calendars", "aeon-rotation.ics"); !strings.HasSuffix(notice, " to "+want) {
+		t.Errorf("exportRotation() notice = %q, want the path %s", notice, want)
+	}
+
+	m.lastMeeting = meetingQuery{}
+	if _, err := m.exportRotation(); err == nil {
+		t.Errorf("exportRotation() without a rotation succeeded, want error")
+	}
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// formatTable renders rows as left-aligned columns, each row indented by
+// indent. Trailing empty cells leave no trailing space.
+func formatTable(rows [][]string, indent string) string {
+	var widths []int
+	for _, row := range rows {
+		for i, cell := range row {
+			if i == len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[i] = max(widths[i], lipgloss.Width(cell))
+		}
+	}
+
+	lines := make([]string, len(rows))
+	for r, row := range rows {
+		var b strings.Builder
+		b.WriteString(indent)
+		for i, cell := range row {
+			b.WriteString(cell)
+			if i < len(row)-1 {
+				b.WriteString(strings.Repeat(" ", widths[i]-lipgloss.Width(cell)+2))
+			}
+		}
+		lines[r] = strings.TrimRight(b.String(), " ")
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// formatDayDelta marks a date that differs from the reference date: "+1",
+// "-1", or empty for the same day
+func formatDayDelta(days int) string {
+	if days == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%+d", days)
+}
+
+// zoneAbbreviation returns the abbreviation of t's zone, such as "EST", or an
+// empty string for zones that only report their offset ("+04")
+func zoneAbbreviation(t time.Time) string {
+	abbreviation := t.Format("MST")
+	if strings.HasPrefix(abbreviation, "+") || strings.HasPrefix(abbreviation, "-") {
+		return ""
+	}
+	return abbreviation
+}
+
+// formatUTCOffset renders the UTC offset of t: "UTC-05:00"
+func formatUTCOffset(t time.Time) string {
+	return t.Format("UTC-07:00")
+}
+
+// formatOffsetDifference renders a difference between UTC offsets: "+6h",
+// "-9h30m", or "±0h" for none
+func formatOffsetDifference(d time.Duration) string {
+	if d == 0 {
+		return "±0h"
+	}
+
+	sign := "+"
+	if d < 0 {
+		sign = "-"
+		d = -d
+	}
+
+	text := fmt.Sprintf("%s%dh", sign, int(d/time.Hour))
+	if minutes := int(d % time.Hour / time.Minute); minutes > 0 {
+		text += fmt.Sprintf("%dm", minutes)
+	}
+	return text
+}
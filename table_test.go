@@ -0,0 +1,38 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatTable(t *testing.T) {
+	rows := [][]string{
+		{"Berlin", "9:00 PM", "+6h", ""},
+		{"Los Angeles", "12:00 PM", "-3h", "+1"},
+	}
+
+	want := "  Berlin       9:00 PM   +6h\n" +
+		"  Los Angeles  12:00 PM  -3h  +1"
+	if got := formatTable(rows, "  "); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestFormatOffsetDifference(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "±0h"},
+		{6 * time.Hour, "+6h"},
+		{-9 * time.Hour, "-9h"},
+		{10*time.Hour + 30*time.Minute, "+10h30m"},
+		{-(3*time.Hour + 45*time.Minute), "-3h45m"},
+	}
+
+	for _, tt := range tests {
+		if got := formatOffsetDifference(tt.d); got != tt.want {
+			t.Errorf("formatOffsetDifference(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Timeline cell styles. Each kind also has its own character, so the grid
+// reads without colors.
+var (
+	timelineWorkStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("34"))
+
+	timelineOverlapStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("214"))
+
+	timelineOffStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("240"))
+
+	timelineSleepStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("24"))
+)
+
+// timelineSlot picks the longest slot length, down to 15 minutes, that
+// fits hours into width columns. It returns 0 when even hourly slots don't
+// fit.
+func timelineSlot(hours, width int) time.Duration {
+	for _, perHour := range []int{4, 2, 1} {
+		if hours*perHour <= width {
+			return time.Hour / time.Duration(perHour)
+		}
+	}
+	return 0
+}
+
+// containsInstant reports whether any of the intervals contains t
+func containsInstant(intervals []interval, t time.Time) bool {
+	for _, i := range intervals {
+		if !t.Before(i.Start) && t.Before(i.End) {
+			return true
+		}
+	}
+	return false
+}
+
+// intersectsSpan reports whether any of the intervals overlaps span
+func intersectsSpan(intervals []interval, span interval) bool {
+	for _, i := range intervals {
+		if i.Start.Before(span.End) && span.Start.Before(i.End) {
+			return true
+		}
+	}
+	return false
+}
+
+// renderTimeline draws one row per participant over the given hours from
+// from, scaled to width columns. Each cell shows whether the participant
+// is working, off or asleep during sleepHours, and working cells where
+// everyone overlaps are highlighted. An hour ruler in the user's time runs
+// along the top.
+func renderTimeline(participants []participant, from time.Time, hours, width int, sleepHours workingHours, f timeFormatter) string {
+	labelWidth := len(localZoneName)
+	for _, p := range participants {
+		labelWidth = max(labelWidth, lipgloss.Width(p.Name))
+	}
+
+	slot := timelineSlot(hours, width-labelWidth-2)
+	if slot == 0 {
+		return helpStyle.Render("Widen the terminal to see the timeline")
+	}
+
+	to := from.Add(time.Duration(hours) * time.Hour)
+	slots := int(to.Sub(from) / slot)
+	overlaps := meetingOverlaps(participants, from, to)
+
+	// Label every hour when there is room, otherwise every 2 or 3 hours
+	labelStep := 3
+	switch slot {
+	case 15 * time.Minute:
+		labelStep = 1
+	case 30 * time.Minute:
+		labelStep = 2
+	}
+
+	var ruler strings.Builder
+	for i := 0; i < slots; {
+		t := from.Add(time.Duration(i) * slot).In(time.Local)
+		if t.Minute() == 0 && t.Hour()%labelStep == 0 {
+			label := fmt.Sprintf("%-*d", int(time.Hour/slot)*labelStep, t.Hour())
+			label = label[:min(len(label), slots-i)]
+			ruler.WriteString(label)
+			i += len(label)
+			continue
+		}
+		ruler.WriteString(" ")
+		i++
+	}
+
+	label := func(name string) string {
+		return name + strings.Repeat(" ", labelWidth-lipgloss.Width(name)+2)
+	}
+
+	rows := []string{label(localZoneName) + strings.TrimRight(ruler.String(), " ")}
+	for _, p := range participants {
+		work := workingWindows(p, from, to)
+		sleep := workingWindows(participant{Loc: p.Loc, Hours: sleepHours}, from, to)
+
+		var cells strings.Builder
+		for i := 0; i < slots; i++ {
+			span := interval{Start: from.Add(time.Duration(i) * slot), End: from.Add(time.Duration(i+1) * slot)}
+			middle := span.Start.Add(slot / 2)
+			switch {
+			case containsInstant(work, middle) && intersectsSpan(overlaps, span):
+				cells.WriteString(timelineOverlapStyle.Render("█"))
+			case containsInstant(work, middle):
+				cells.WriteString(timelineWorkStyle.Render("▓"))
+			case containsInstant(sleep, middle):
+				cells.WriteString(timelineSleepStyle.Render("░"))
+			default:
+				cells.WriteString(timelineOffStyle.Render("▒"))
+			}
+		}
+		rows = append(rows, label(p.Name)+cells.String())
+	}
+
+	legend := strings.Join([]string{
+		timelineOverlapStyle.Render("█") + " overlap",
+		timelineWorkStyle.Render("▓") + " working",
+		timelineOffStyle.Render("▒") + " off hours",
+		timelineSleepStyle.Render("░") + " asleep (" + f.Shifts(sleepHours) + ")",
+	}, "  ")
+	rows = append(rows, "", label("")+legend)
+
+	return strings.Join(rows, "\n")
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestTimelineSlot(t *testing.T) {
+	tests := []struct {
+		hours int
+		width int
+		want  time.Duration
+	}{
+		{24, 120, 15 * time.Minute},
+		{24, 96, 15 * time.Minute},
+		{24, 95, 30 * time.Minute},
+		{24, 48, 30 * time.Minute},
+		{24, 30, time.Hour},
+		{24, 23, 0},
+		{48, 120, 30 * time.Minute},
+		{48, 60, time.Hour},
+	}
+
+	for _, tt := range tests {
+		if got := timelineSlot(tt.hours, tt.width); got != tt.want {
+			t.Errorf("timelineSlot(%d, %d) = %v, want %v", tt.hours, tt.width, got, tt.want)
+		}
+	}
+}
+
+func TestRenderTimeline(t *testing.T) {
+	nyc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	london, err := time.LoadLocation("Europe/London")
+	if err != nil {
+		t.Fatal(err)
+	}
+	participants := []participant{
+		{Name: "NYC", Loc: nyc, Hours: defaultWorkingHours},
+		{Name: "London", Loc: london, Hours: defaultWorkingHours},
+	}
+	from := time.Date(2026, 1, 20, 0, 0, 0, 0, time.UTC)
+
+	// Hourly cells, since the labels take 8 of the 40 columns
+	lines := strings.Split(ansi.Strip(renderTimeline(participants, from, 24, 40, defaultSleepHours, defaultFormatter)), "\n")
+	want := map[string]string{
+		"NYC":    "▒▒▒▒░░░░░░░░▒▒███▓▓▓▓▓▒▒",
+		"London": "░░░░░░░▒▒▓▓▓▓▓███▒▒▒▒▒▒░",
+	}
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		if cells, ok := want[fields[0]]; ok {
+			if fields[1] != cells {
+				t.Errorf("%s row = %s, want %s", fields[0], fields[1], cells)
+			}
+			delete(want, fields[0])
+		}
+	}
+	if len(want) > 0 {
+		t.Errorf("renderTimeline() is missing rows %v:\n%s", want, strings.Join(lines, "\n"))
+	}
+
+	if got := renderTimeline(participants, from, 24, 20, defaultSleepHours, defaultFormatter); !strings.Contains(got, "Widen the terminal") {
+		t.Errorf("renderTimeline() at width 20 = %q, want a hint to widen the terminal", got)
+	}
+
+	// Configured sleep hours change the rows and the legend
+	sleep, err := parseWorkingHours("1-5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := ansi.Strip(renderTimeline(participants, from, 24, 40, sleep, newTimeFormatter(FormatConfig{Clock: "24h"})))
+	if !strings.Contains(got, "▒░░░░▒▒▒▒▓▓▓▓▓███▒▒▒▒▒▒▒") {
+		t.Errorf("renderTimeline() with sleep hours 1-5 has the wrong London row:\n%s", got)
+	}
+	if !strings.Contains(got, "asleep (01:00 - 05:00)") {
+		t.Errorf("renderTimeline() legend doesn't show sleep hours 1-5:\n%s", got)
+	}
+}
@@ -49,7 +49,8 @@ var ManualAliases = map[string]string{
 	"bkk":           "bangkok",
 	"del":           "delhi",
 	"bom":           "mumbai",
-	"blr":           "bangalore",
+	"blr":           "bengaluru",
+	"bangalore":     "bengaluru",
 	
 	// Australia
 	"syd":           "sydney",
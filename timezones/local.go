@@ -0,0 +1,53 @@
+package timezones
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// lookupLocalName works out the name behind time.Local on first use
+var lookupLocalName = sync.OnceValue(func() string {
+	return readLocalName(time.Local.String(), "/etc/localtime", "/etc/timezone")
+})
+
+// LocalName returns the IANA name of the zone behind time.Local. Go names
+// that zone "Local" unless $TZ is set, so it is read from the localtime
+// link or /etc/timezone instead. It is empty when the zone can't be worked
+// out.
+func LocalName() string {
+	return lookupLocalName()
+}
+
+// readLocalName returns name unless it is "Local", and otherwise the zone
+// the localtime file links to, or the one named in the timezone file
+func readLocalName(name, localtime, timezone string) string {
+	if name != "Local" {
+		return name
+	}
+
+	if target, err := filepath.EvalSymlinks(localtime); err == nil {
+		if _, zone, ok := strings.Cut(target, "zoneinfo/"); ok {
+			return zone
+		}
+	}
+	if data, err := os.ReadFile(timezone); err == nil {
+		return strings.TrimSpace(string(data))
+	}
+	return ""
+}
+
+// Name returns the IANA name of loc, looking through the user's own zone,
+// named "Local", to the zone it stands for
+func Name(loc *time.Location) string {
+	name := loc.String()
+	if name != "Local" {
+		return name
+	}
+	if local := LocalName(); local != "" {
+		return local
+	}
+	return name
+}
@@ -0,0 +1,88 @@
+package timezones
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestReadLocalName(t *testing.T) {
+	dir := t.TempDir()
+	zoneFile := filepath.Join(dir, "zoneinfo", "Asia", "Riyadh")
+	if err := os.MkdirAll(filepath.Dir(zoneFile), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(zoneFile, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	linked := filepath.Join(dir, "localtime")
+	if err := os.Symlink(zoneFile, linked); err != nil {
+		t.Fatal(err)
+	}
+	copied := filepath.Join(dir, "localtime-copy")
+	if err := os.WriteFile(copied, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	timezone := filepath.Join(dir, "timezone")
+	if err := os.WriteFile(timezone, []byte("Africa/Cairo\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	missing := filepath.Join(dir, "missing")
+
+	tests := []struct {
+		name      string
+		zone      string
+		localtime string
+		timezone  string
+		want      string
+	}{
+		{"set by TZ", "Europe/Berlin", linked, timezone, "Europe/Berlin"},
+		{"localtime link", "Local", linked, timezone, "Asia/Riyadh"},
+		{"timezone file", "Local", copied, timezone, "Africa/Cairo"},
+		{"unknown", "Local", missing, missing, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := readLocalName(tt.zone, tt.localtime, tt.timezone); got != tt.want {
+				t.Errorf("readLocalName(%q) = %q, want %q", tt.zone, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsWeekendLocal(t *testing.T) {
+	lookup := lookupLocalName
+	t.Cleanup(func() { lookupLocalName = lookup })
+
+	// The user's own zone as read from /etc/localtime: named "Local"
+	local := time.FixedZone("Local", 3*60*60)
+
+	tests := []struct {
+		localName string
+		wantName  string
+		weekend   []time.Weekday
+	}{
+		{"Asia/Riyadh", "Asia/Riyadh", []time.Weekday{time.Friday, time.Saturday}},
+		{"Europe/Berlin", "Europe/Berlin", []time.Weekday{time.Saturday, time.Sunday}},
+		{"", "Local", []time.Weekday{time.Saturday, time.Sunday}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.wantName, func(t *testing.T) {
+			lookupLocalName = func() string { return tt.localName }
+
+			if got := Name(local); got != tt.wantName {
+				t.Errorf("Name(Local) = %q, want %q", got, tt.wantName)
+			}
+			for day := time.Sunday; day <= time.Saturday; day++ {
+				want := day == tt.weekend[0] || day == tt.weekend[1]
+				if got := IsWeekend(local, day); got != want {
+					t.Errorf("IsWeekend(Local, %s) = %v, want %v", day, got, want)
+				}
+			}
+		})
+	}
+}
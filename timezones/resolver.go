@@ -8,9 +8,29 @@ import (
 
 // Resolve takes a city name/alias and returns the IANA timezone location
 func Resolve(name string) (*time.Location, error) {
-	// Normalize input
-	normalized := strings.ToLower(strings.TrimSpace(name))
-	normalized = strings.ReplaceAll(normalized, "_", " ")
+	if loc, ok := Lookup(name); ok {
+		return loc, nil
+	}
+
+	normalized := normalize(name)
+
+	// Provide helpful suggestions
+	suggestions := getSuggestions(normalized)
+	if len(suggestions) > 0 {
+		return nil, fmt.Errorf("unknown location '%s'. Did you mean: %s?", name, strings.Join(suggestions, ", "))
+	}
+
+	return nil, fmt.Errorf("unknown location: %s", name)
+}
+
+// Lookup resolves a city name/alias like Resolve, but reports failure with a
+// flag instead of building suggestions. It is cheap enough to call for every
+// candidate span while tokenizing a query.
+func Lookup(name string) (*time.Location, bool) {
+	normalized := normalize(name)
+	if normalized == "" {
+		return nil, false
+	}
 
 	// Check manual aliases first (NYC -> new york)
 	if canonical, ok := ManualAliases[normalized]; ok {
@@ -19,7 +39,8 @@ func Resolve(name string) (*time.Location, error) {
 
 	// Check generated cities map
 	if tz, ok := GeneratedCities[normalized]; ok {
-		return time.LoadLocation(tz)
+		loc, err := time.LoadLocation(tz)
+		return loc, err == nil
 	}
 
 	// Fallback: try IANA timezone variations
@@ -36,17 +57,28 @@ func Resolve(name string) (*time.Location, error) {
 
 	for _, v := range variations {
 		if loc, err := time.LoadLocation(v); err == nil {
-			return loc, nil
+			return loc, true
 		}
 	}
 
-	// Provide helpful suggestions
-	suggestions := getSuggestions(normalized)
-	if len(suggestions) > 0 {
-		return nil, fmt.Errorf("unknown location '%s'. Did you mean: %s?", name, strings.Join(suggestions, ", "))
+	return nil, false
+}
+
+// Canonical returns the city a name or alias stands for, lowercased:
+// "NYC" and "new_york" both give "new york". Names that are not aliases
+// are only normalized.
+func Canonical(name string) string {
+	normalized := normalize(name)
+	if canonical, ok := ManualAliases[normalized]; ok {
+		return canonical
 	}
+	return normalized
+}
 
-	return nil, fmt.Errorf("unknown location: %s", name)
+// normalize lowercases a name and treats underscores as spaces
+func normalize(name string) string {
+	normalized := strings.ToLower(strings.TrimSpace(name))
+	return strings.ReplaceAll(normalized, "_", " ")
 }
 
 // getSuggestions finds similar city names for typos
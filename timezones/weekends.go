@@ -0,0 +1,35 @@
+package timezones
+
+import "time"
+
+// FridaySaturdayWeekend lists IANA zones where the working week runs Sunday to
+// Thursday. Every other zone is treated as having a Saturday/Sunday weekend.
+var FridaySaturdayWeekend = map[string]bool{
+	"Africa/Algiers":  true,
+	"Africa/Cairo":    true,
+	"Africa/Khartoum": true,
+	"Africa/Tripoli":  true,
+	"Asia/Aden":       true,
+	"Asia/Amman":      true,
+	"Asia/Baghdad":    true,
+	"Asia/Bahrain":    true,
+	"Asia/Damascus":   true,
+	"Asia/Dhaka":      true,
+	"Asia/Gaza":       true,
+	"Asia/Hebron":     true,
+	"Asia/Jerusalem":  true,
+	"Asia/Kuwait":     true,
+	"Asia/Muscat":     true,
+	"Asia/Qatar":      true,
+	"Asia/Riyadh":     true,
+	"Asia/Tel_Aviv":   true,
+}
+
+// IsWeekend reports whether day falls on the weekend in the given location.
+// The user's own zone is looked up by its IANA name.
+func IsWeekend(loc *time.Location, day time.Weekday) bool {
+	if loc != nil && FridaySaturdayWeekend[Name(loc)] {
+		return day == time.Friday || day == time.Saturday
+	}
+	return day == time.Saturday || day == time.Sunday
+}